@@ -2,8 +2,14 @@ package main
 
 import (
 	//"fmt"
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/didanslmn/movie-reservation-system.git/config"
 
@@ -39,19 +45,50 @@ func main() {
 	if jwtSecret == "" {
 		log.Fatalf("JWT_SECRET environment variable is not set")
 	}
-	r := router.SetupRouter(db, jwtSecret)
-	if r == nil {
-		log.Fatalf("Failed to setup router")
+	// ctx dibatalkan saat proses menerima sinyal berhenti, sehingga worker
+	// latar belakang dan server HTTP berhenti dengan rapi
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pricingCfg, err := config.LoadPricingConfig()
+	if err != nil {
+		log.Fatalf("Failed to load pricing config: %v", err)
+	}
+
+	r, err := router.SetupRouter(
+		ctx,
+		db,
+		jwtSecret,
+		config.LoadAppConfig(),
+		config.LoadReservationConfig(),
+		config.LoadTicketConfig(jwtSecret),
+		config.LoadNotificationConfig(),
+		config.LoadGuestConfig(jwtSecret),
+		pricingCfg,
+	)
+	if err != nil {
+		log.Fatalf("Failed to setup router: %v", err)
 	}
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080" // default port
 	}
 
+	srv := &http.Server{Addr: ":" + port, Handler: r}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("failed to shut down server: %v", err)
+		}
+	}()
+
 	log.Printf("Server running at http://localhost:%s", port)
-	if err := r.Run(":" + port); err != nil {
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		log.Fatalf("failed to run server: %v", err)
 	}
+	log.Println("Server stopped")
 }
 
 /*
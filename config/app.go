@@ -0,0 +1,33 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+type AppConfig struct {
+	// PublicBaseURL adalah alamat publik API (scheme + host) untuk menyusun link
+	// yang dibagikan ke user, misalnya feed kalender dan magic link guest.
+	// Tidak diambil dari header request agar tidak bisa dipalsukan klien.
+	PublicBaseURL string
+	// TrustedProxies adalah IP/CIDR reverse proxy yang header X-Forwarded-For-nya
+	// dipercaya untuk menentukan IP klien; kosong berarti tidak ada proxy.
+	TrustedProxies []string
+}
+
+func LoadAppConfig() AppConfig {
+	baseURL := os.Getenv("PUBLIC_BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return AppConfig{
+		PublicBaseURL:  strings.TrimRight(baseURL, "/"),
+		TrustedProxies: proxies,
+	}
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+type GuestConfig struct {
+	// TokenSecret untuk menurunkan token magic link (HMAC-SHA256)
+	TokenSecret string
+	// CheckoutLimitPerIP dan CheckoutLimitPerEmail adalah jumlah guest checkout
+	// yang diizinkan per CheckoutLimitWindow
+	CheckoutLimitPerIP    int
+	CheckoutLimitPerEmail int
+	CheckoutLimitWindow   time.Duration
+}
+
+// LoadGuestConfig memakai GUEST_TOKEN_SECRET, atau fallbackSecret jika tidak diset.
+func LoadGuestConfig(fallbackSecret string) GuestConfig {
+	secret := os.Getenv("GUEST_TOKEN_SECRET")
+	if secret == "" {
+		secret = fallbackSecret
+	}
+	return GuestConfig{
+		TokenSecret:           secret,
+		CheckoutLimitPerIP:    getEnvInt("GUEST_CHECKOUT_LIMIT_PER_IP", 20),
+		CheckoutLimitPerEmail: getEnvInt("GUEST_CHECKOUT_LIMIT_PER_EMAIL", 5),
+		CheckoutLimitWindow:   time.Duration(getEnvInt("GUEST_CHECKOUT_LIMIT_WINDOW_MINUTES", 60)) * time.Minute,
+	}
+}
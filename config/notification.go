@@ -0,0 +1,40 @@
+package config
+
+import "os"
+
+type NotificationConfig struct {
+	// Driver adalah "log" (default) atau "smtp"
+	Driver string
+	// LogFile, jika diisi, menjadi tujuan notifier log; kosong berarti log aplikasi
+	LogFile string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// QueueSize adalah jumlah notifikasi yang bisa antre sebelum dibuang
+	QueueSize int
+}
+
+func LoadNotificationConfig() NotificationConfig {
+	driver := os.Getenv("NOTIFY_DRIVER")
+	if driver == "" {
+		driver = "log"
+	}
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = "no-reply@movie-reservation.local"
+	}
+	return NotificationConfig{
+		Driver:       driver,
+		LogFile:      os.Getenv("NOTIFY_LOG_FILE"),
+		SMTPHost:     os.Getenv("SMTP_HOST"),
+		SMTPPort:     getEnvInt("SMTP_PORT", 1025),
+		SMTPUsername: os.Getenv("SMTP_USERNAME"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:     from,
+		QueueSize:    getEnvInt("NOTIFY_QUEUE_SIZE", 100),
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+	// data zona waktu ikut dibundel agar PRICING_TIMEZONE tetap bisa dibaca
+	// di image tanpa tzdata
+	_ "time/tzdata"
+)
+
+type PricingConfig struct {
+	// Location adalah zona waktu bioskop, dipakai untuk mencocokkan hari dan
+	// jam price rule dengan jadwal showtime
+	Location *time.Location
+}
+
+// LoadPricingConfig memakai PRICING_TIMEZONE (nama IANA), default Asia/Jakarta.
+func LoadPricingConfig() (PricingConfig, error) {
+	name := os.Getenv("PRICING_TIMEZONE")
+	if name == "" {
+		name = "Asia/Jakarta"
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return PricingConfig{}, fmt.Errorf("invalid PRICING_TIMEZONE %q: %w", name, err)
+	}
+	return PricingConfig{Location: location}, nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+type ReservationConfig struct {
+	// HoldDuration adalah lama seat ditahan untuk reservation pending
+	HoldDuration time.Duration
+	// SweepInterval adalah jeda antar pengecekan reservation yang kedaluwarsa
+	SweepInterval time.Duration
+	// CompleteInterval adalah jeda antar pengecekan showtime yang sudah selesai
+	CompleteInterval time.Duration
+	// CancelCutoff adalah batas minimal sebelum showtime mulai agar user bisa membatalkan sendiri
+	CancelCutoff time.Duration
+	// IdempotencyTimeout adalah lama Idempotency-Key tanpa response dianggap
+	// masih diproses; setelah itu retry boleh mengambil alih key
+	IdempotencyTimeout time.Duration
+
+	// batas default booking per user, bisa di-override admin per user
+	MaxSeatsPerReservation int
+	MaxSeatsPerShowtime    int
+	MaxActiveReservations  int
+}
+
+func LoadReservationConfig() ReservationConfig {
+	return ReservationConfig{
+		HoldDuration:  time.Duration(getEnvInt("RESERVATION_HOLD_MINUTES", 10)) * time.Minute,
+		SweepInterval: time.Duration(getEnvInt("RESERVATION_SWEEP_INTERVAL_SECONDS", 30)) * time.Second,
+		CancelCutoff:  time.Duration(getEnvInt("RESERVATION_CANCEL_CUTOFF_HOURS", 2)) * time.Hour,
+
+		CompleteInterval: time.Duration(getEnvInt("RESERVATION_COMPLETE_INTERVAL_MINUTES", 5)) * time.Minute,
+
+		IdempotencyTimeout: time.Duration(getEnvInt("RESERVATION_IDEMPOTENCY_TIMEOUT_SECONDS", 60)) * time.Second,
+
+		MaxSeatsPerReservation: getEnvInt("RESERVATION_MAX_SEATS", 10),
+		MaxSeatsPerShowtime:    getEnvInt("RESERVATION_MAX_SEATS_PER_SHOWTIME", 10),
+		MaxActiveReservations:  getEnvInt("RESERVATION_MAX_ACTIVE", 5),
+	}
+}
+
+func getEnvInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("invalid value for %s (%q), using default %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"os"
+	"time"
+)
+
+type TicketConfig struct {
+	// Secret untuk menandatangani token tiket (HMAC-SHA256)
+	Secret string
+	// CheckInOpensBefore adalah berapa lama sebelum showtime mulai check-in dibuka
+	CheckInOpensBefore time.Duration
+}
+
+// LoadTicketConfig memakai TICKET_SECRET, atau fallbackSecret jika tidak diset.
+func LoadTicketConfig(fallbackSecret string) TicketConfig {
+	secret := os.Getenv("TICKET_SECRET")
+	if secret == "" {
+		secret = fallbackSecret
+	}
+	return TicketConfig{
+		Secret:             secret,
+		CheckInOpensBefore: time.Duration(getEnvInt("TICKET_CHECKIN_OPEN_MINUTES", 60)) * time.Minute,
+	}
+}
@@ -0,0 +1,8 @@
+package response
+
+import "time"
+
+type CalendarFeedResponse struct {
+	FeedURL   string    `json:"feed_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/calendar/service"
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const icsContentType = "text/calendar; charset=utf-8"
+
+type CalendarHandler struct {
+	service service.CalendarService
+}
+
+func NewCalendarHandler(service service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{service: service}
+}
+
+func (h *CalendarHandler) GetReservationEvent(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reservation ID", err)
+		return
+	}
+
+	ics, err := h.service.GetReservationEvent(c.Request.Context(), user, uint(id))
+	if err != nil {
+		respondCalendarError(c, "Failed to export reservation", err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="reservation-%d.ics"`, id))
+	c.Data(http.StatusOK, icsContentType, ics)
+}
+
+// GetFeed tidak memakai JWT; token di URL adalah kredensialnya.
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	ics, err := h.service.GetFeed(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondCalendarError(c, "Failed to fetch calendar feed", err)
+		return
+	}
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, icsContentType, ics)
+}
+
+func (h *CalendarHandler) GetFeedURL(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.GetFeedURL(c.Request.Context(), user.ID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch calendar feed", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Calendar feed fetched successfully", res)
+}
+
+func (h *CalendarHandler) RotateFeedURL(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.RotateFeedURL(c.Request.Context(), user.ID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to rotate calendar feed", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Calendar feed rotated successfully", res)
+}
+
+func respondCalendarError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidToken):
+		utils.RespondWithError(c, http.StatusNotFound, "Calendar feed not found", err)
+	case errors.Is(err, service.ErrForbidden):
+		utils.RespondWithError(c, http.StatusForbidden, msg, err)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, "Reservation not found", err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, msg, err)
+	}
+}
@@ -0,0 +1,16 @@
+package mapper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/calendar/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/calendar/model"
+)
+
+func ToCalendarFeedResponse(token *model.CalendarToken, baseURL string) *response.CalendarFeedResponse {
+	return &response.CalendarFeedResponse{
+		FeedURL:   fmt.Sprintf("%s/api/v1/calendar/%s/feed.ics", strings.TrimRight(baseURL, "/"), token.Token),
+		CreatedAt: token.CreatedAt,
+	}
+}
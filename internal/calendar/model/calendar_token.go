@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// CalendarToken adalah secret per user untuk feed ICS. Aplikasi kalender tidak
+// bisa mengirim header JWT, jadi token ini yang menjadi kredensial di URL feed.
+type CalendarToken struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;uniqueIndex"`
+	Token     string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
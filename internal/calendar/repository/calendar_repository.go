@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/calendar/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CalendarRepository interface {
+	GetByUserID(ctx context.Context, userID uint) (*model.CalendarToken, error)
+	GetByToken(ctx context.Context, token string) (*model.CalendarToken, error)
+	Upsert(ctx context.Context, token *model.CalendarToken) error
+}
+
+type calendarRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarRepository(db *gorm.DB) CalendarRepository {
+	return &calendarRepository{db: db}
+}
+
+// GetByUserID mengembalikan nil tanpa error jika user belum punya token.
+func (r *calendarRepository) GetByUserID(ctx context.Context, userID uint) (*model.CalendarToken, error) {
+	var token model.CalendarToken
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		utils.ErrorLogger.Printf("Error to get calendar token (user: %d): %v", userID, err)
+		return nil, fmt.Errorf("failed to get calendar token: %w", err)
+	}
+	return &token, nil
+}
+
+func (r *calendarRepository) GetByToken(ctx context.Context, token string) (*model.CalendarToken, error) {
+	var calendarToken model.CalendarToken
+	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&calendarToken).Error; err != nil {
+		return nil, fmt.Errorf("failed to get calendar token: %w", err)
+	}
+	return &calendarToken, nil
+}
+
+// Upsert membuat token baru atau mengganti token lama milik user (rotate).
+func (r *calendarRepository) Upsert(ctx context.Context, token *model.CalendarToken) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token", "created_at", "updated_at"}),
+	}).Create(token).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to save calendar token (user: %d): %v", token.UserID, err)
+		return fmt.Errorf("failed to save calendar token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package router
+
+import (
+	"github.com/didanslmn/movie-reservation-system.git/internal/calendar/handler"
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	"github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarFeedRoutes didaftarkan tanpa JWT karena aplikasi kalender hanya
+// mengakses URL feed.
+func CalendarFeedRoutes(rg *gin.RouterGroup, h *handler.CalendarHandler) {
+	rg.GET("/calendar/:token/feed.ics", h.GetFeed)
+}
+
+func CalendarRoutes(rg *gin.RouterGroup, h *handler.CalendarHandler, jwtSecret string) {
+	// User & Admin dapat mengekspor reservation dan mengatur URL feed
+	reservations := rg.Group("/reservations")
+	reservations.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	reservations.Use(middleware.RoleBasedAccess(model.RoleUser, model.RoleAdmin))
+	{
+		reservations.GET("/:id/event.ics", h.GetReservationEvent)
+	}
+
+	user := rg.Group("/user/calendar")
+	user.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	user.Use(middleware.RoleBasedAccess(model.RoleUser, model.RoleAdmin))
+	{
+		user.GET("", h.GetFeedURL)
+		user.POST("/rotate", h.RotateFeedURL)
+	}
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/calendar/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/calendar/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/calendar/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/calendar/repository"
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	reservationRepository "github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrForbidden    = errors.New("reservation belongs to another user")
+	ErrInvalidToken = errors.New("invalid calendar token")
+)
+
+type CalendarService interface {
+	GetReservationEvent(ctx context.Context, actor *userModel.User, reservationID uint) ([]byte, error)
+	GetFeed(ctx context.Context, token string) ([]byte, error)
+	GetFeedURL(ctx context.Context, userID uint) (*response.CalendarFeedResponse, error)
+	RotateFeedURL(ctx context.Context, userID uint) (*response.CalendarFeedResponse, error)
+}
+
+type calendarService struct {
+	calendarRepo    repository.CalendarRepository
+	reservationRepo reservationRepository.ReservationRepository
+	// baseURL adalah alamat publik API untuk menyusun URL feed
+	baseURL string
+}
+
+func NewCalendarService(calendarRepo repository.CalendarRepository, reservationRepo reservationRepository.ReservationRepository, baseURL string) CalendarService {
+	return &calendarService{
+		calendarRepo:    calendarRepo,
+		reservationRepo: reservationRepo,
+		baseURL:         baseURL,
+	}
+}
+
+func (s *calendarService) GetReservationEvent(ctx context.Context, actor *userModel.User, reservationID uint) ([]byte, error) {
+	reservation, err := s.reservationRepo.GetByID(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.Role != userModel.RoleAdmin && reservation.UserID != actor.ID {
+		return nil, ErrForbidden
+	}
+	return buildCalendar(reservation.Showtime.Movie.Title, []icsEvent{toEvent(reservation)}, time.Now()), nil
+}
+
+// GetFeed mengembalikan reservation confirmed yang akan datang milik pemilik token.
+func (s *calendarService) GetFeed(ctx context.Context, token string) ([]byte, error) {
+	calendarToken, err := s.calendarRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	reservations, err := s.reservationRepo.GetAll(ctx, reservationRepository.ReservationFilter{
+		UserID: calendarToken.UserID,
+		Status: reservationModel.StatusConfirmed,
+		From:   &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]icsEvent, 0, len(reservations))
+	for i := range reservations {
+		events = append(events, toEvent(&reservations[i]))
+	}
+	return buildCalendar("Movie reservations", events, now), nil
+}
+
+// GetFeedURL mengembalikan URL feed user dan membuat token jika belum ada.
+func (s *calendarService) GetFeedURL(ctx context.Context, userID uint) (*response.CalendarFeedResponse, error) {
+	token, err := s.calendarRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		if token, err = s.issueToken(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return mapper.ToCalendarFeedResponse(token, s.baseURL), nil
+}
+
+// RotateFeedURL mengganti token feed, URL lama langsung tidak berlaku.
+func (s *calendarService) RotateFeedURL(ctx context.Context, userID uint) (*response.CalendarFeedResponse, error) {
+	token, err := s.issueToken(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToCalendarFeedResponse(token, s.baseURL), nil
+}
+
+func (s *calendarService) issueToken(ctx context.Context, userID uint) (*model.CalendarToken, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+	token := &model.CalendarToken{
+		UserID:    userID,
+		Token:     hex.EncodeToString(b),
+		CreatedAt: time.Now(),
+	}
+	if err := s.calendarRepo.Upsert(ctx, token); err != nil {
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Calendar feed token issued (user: %d)", userID)
+	return token, nil
+}
+
+func toEvent(r *reservationModel.Reservation) icsEvent {
+	seats := make([]string, 0, len(r.Seats))
+	for _, seat := range r.Seats {
+		seats = append(seats, seat.SeatNumber)
+	}
+	sort.Strings(seats)
+
+	return icsEvent{
+		UID:         eventUID(r.ID),
+		Start:       r.Showtime.StartTime,
+		End:         r.Showtime.EndTime,
+		Summary:     r.Showtime.Movie.Title,
+		Location:    r.Showtime.CinemaHall.Name,
+		Description: fmt.Sprintf("Booking #%d\nSeats: %s", r.ID, strings.Join(seats, ", ")),
+		Status:      eventStatus(r.Status),
+	}
+}
+
+func eventStatus(status string) string {
+	switch status {
+	case reservationModel.StatusConfirmed, reservationModel.StatusCheckedIn, reservationModel.StatusCompleted, reservationModel.StatusNoShow:
+		return "CONFIRMED"
+	case reservationModel.StatusPending:
+		return "TENTATIVE"
+	default:
+		return "CANCELLED"
+	}
+}
@@ -1,10 +1,12 @@
 package request
 
 type CreateCinemaHallRequest struct {
-	Name     string `json:"name" binding:"required"`
-	Capacity int    `json:"capacity" binding:"required"`
+	Name          string `json:"name" binding:"required"`
+	Capacity      int    `json:"capacity" binding:"required"`
+	SeatGapPolicy string `json:"seat_gap_policy" binding:"omitempty,oneof=off warn reject"`
 }
 type UpdateCinemaHallRequest struct {
-	Name     string `json:"name"`
-	Capacity int    `json:"capacity"`
+	Name          string `json:"name"`
+	Capacity      int    `json:"capacity"`
+	SeatGapPolicy string `json:"seat_gap_policy" binding:"omitempty,oneof=off warn reject"`
 }
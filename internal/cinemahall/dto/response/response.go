@@ -1,7 +1,8 @@
 package response
 
 type CinemaHallResponse struct {
-	ID       uint   `json:"id"`
-	Name     string `json:"name"`
-	Capacity int    `json:"capacity"`
+	ID            uint   `json:"id"`
+	Name          string `json:"name"`
+	Capacity      int    `json:"capacity"`
+	SeatGapPolicy string `json:"seat_gap_policy"`
 }
@@ -8,9 +8,10 @@ import (
 
 func ToCinemaHallResponse(ch *model.CinemaHall) *response.CinemaHallResponse {
 	return &response.CinemaHallResponse{
-		ID:       ch.ID,
-		Name:     ch.Name,
-		Capacity: ch.Capacity,
+		ID:            ch.ID,
+		Name:          ch.Name,
+		Capacity:      ch.Capacity,
+		SeatGapPolicy: ch.SeatGapPolicy,
 	}
 }
 func ToCinemaHallResponses(halls []model.CinemaHall) []response.CinemaHallResponse {
@@ -22,12 +23,20 @@ func ToCinemaHallResponses(halls []model.CinemaHall) []response.CinemaHallRespon
 }
 
 func ToCinemaHallModel(req *request.CreateCinemaHallRequest) *model.CinemaHall {
+	policy := req.SeatGapPolicy
+	if policy == "" {
+		policy = model.SeatGapPolicyWarn
+	}
 	return &model.CinemaHall{
-		Name:     req.Name,
-		Capacity: req.Capacity,
+		Name:          req.Name,
+		Capacity:      req.Capacity,
+		SeatGapPolicy: policy,
 	}
 }
 func UpdateCinemaHallModel(hall *model.CinemaHall, req *request.UpdateCinemaHallRequest) {
 	hall.Name = req.Name
 	hall.Capacity = req.Capacity
+	if req.SeatGapPolicy != "" {
+		hall.SeatGapPolicy = req.SeatGapPolicy
+	}
 }
@@ -5,9 +5,17 @@ import (
 	"gorm.io/gorm"
 )
 
+// Kebijakan seat kosong tunggal (orphan) di antara reservation.
+const (
+	SeatGapPolicyOff    = "off"
+	SeatGapPolicyWarn   = "warn"
+	SeatGapPolicyReject = "reject"
+)
+
 type CinemaHall struct {
 	gorm.Model
-	Name     string           `gorm:"not null;uniqueIndex"`
-	Capacity int              `gorm:"not null"`
-	Seat     []seatModel.Seat `gorm:"foreignKey:CinemaHallID"`
+	Name          string           `gorm:"not null;uniqueIndex"`
+	Capacity      int              `gorm:"not null"`
+	SeatGapPolicy string           `gorm:"type:varchar(10);not null;default:'warn'"`
+	Seat          []seatModel.Seat `gorm:"foreignKey:CinemaHallID"`
 }
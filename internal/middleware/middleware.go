@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,6 +11,7 @@ import (
 	"slices"
 
 	"github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"gorm.io/gorm"
@@ -101,6 +103,17 @@ func GetUserFromContext(ctx context.Context) (*model.User, bool) {
 	return user, ok
 }
 
+// CurrentUser mengambil user login dari context request untuk handler, dan
+// langsung menulis response 401 jika user tidak ada.
+func CurrentUser(c *gin.Context) (*model.User, bool) {
+	user, ok := GetUserFromContext(c.Request.Context())
+	if !ok || user == nil {
+		utils.RespondWithError(c, http.StatusUnauthorized, "Authentication required", errors.New("user not found in context"))
+		return nil, false
+	}
+	return user, true
+}
+
 func logAndAbort(c *gin.Context, code int, msg string) {
 	log.Printf("[AUTH ERROR] %d %s - %s", code, c.Request.URL.Path, msg)
 	c.AbortWithStatusJSON(code, gin.H{"error": msg})
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitByIP menolak request dengan 429 jika IP klien melewati batas limiter.
+// IP diambil dari c.ClientIP, sehingga header proxy hanya dipercaya dari
+// trusted proxy yang dikonfigurasi di engine.
+func RateLimitByIP(limiter *utils.RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !limiter.Allow(c.ClientIP()) {
+			utils.RespondWithError(c, http.StatusTooManyRequests, "Too many requests, please try again later", utils.ErrRateLimited)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
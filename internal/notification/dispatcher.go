@@ -0,0 +1,125 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+)
+
+// sendTimeout membatasi lama pengiriman satu pesan
+const sendTimeout = 30 * time.Second
+
+// Dispatcher mengantrekan notifikasi dan mengirimnya di goroutine terpisah,
+// sehingga request HTTP tidak menunggu SMTP.
+type Dispatcher struct {
+	notifier Notifier
+	queue    chan Message
+	// location adalah zona waktu bioskop untuk menampilkan jadwal di email
+	location *time.Location
+}
+
+func NewDispatcher(notifier Notifier, queueSize int, location *time.Location) *Dispatcher {
+	return &Dispatcher{
+		notifier: notifier,
+		queue:    make(chan Message, queueSize),
+		location: location,
+	}
+}
+
+// Start menjalankan worker pengirim sampai ctx dibatalkan.
+func (d *Dispatcher) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-d.queue:
+				d.send(ctx, msg)
+			}
+		}
+	}()
+}
+
+// Dispatch tidak pernah blocking; jika antrean penuh pesan dibuang dan dicatat.
+func (d *Dispatcher) Dispatch(msg Message) {
+	select {
+	case d.queue <- msg:
+	default:
+		utils.ErrorLogger.Printf("Notification queue full, dropping message to %s: %s", msg.To, msg.Subject)
+	}
+}
+
+// NotifyReservation dipasang sebagai listener perubahan reservation.
+func (d *Dispatcher) NotifyReservation(_ context.Context, r *reservationModel.Reservation) {
+	msg, ok, err := renderReservation(r, d.location)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to render notification (reservation: %d): %v", r.ID, err)
+		return
+	}
+	if !ok || msg.To == "" {
+		return
+	}
+	d.Dispatch(msg)
+}
+
+// NotifyReschedule dipasang sebagai listener perubahan jadwal showtime.
+func (d *Dispatcher) NotifyReschedule(_ context.Context, r *reservationModel.Reservation) {
+	msg, err := renderReschedule(r, d.location)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to render reschedule notification (reservation: %d): %v", r.ID, err)
+		return
+	}
+	if msg.To == "" {
+		return
+	}
+	d.Dispatch(msg)
+}
+
+// NotifyWaitlistOffer memberi tahu user waitlist bahwa seat sudah ditahan untuknya.
+func (d *Dispatcher) NotifyWaitlistOffer(_ context.Context, r *reservationModel.Reservation) {
+	msg, err := renderWaitlistOffer(r, d.location)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to render waitlist offer notification (reservation: %d): %v", r.ID, err)
+		return
+	}
+	if msg.To == "" {
+		return
+	}
+	d.Dispatch(msg)
+}
+
+// NotifyTransfer memberi tahu penerima bahwa ada reservation yang ditransfer kepadanya.
+func (d *Dispatcher) NotifyTransfer(_ context.Context, t *reservationModel.ReservationTransfer) {
+	msg, err := renderTransfer(t, d.location)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to render transfer notification (transfer: %d): %v", t.ID, err)
+		return
+	}
+	if msg.To == "" {
+		return
+	}
+	d.Dispatch(msg)
+}
+
+// NotifyGuestAccess mengirim magic link reservation ke email guest.
+func (d *Dispatcher) NotifyGuestAccess(_ context.Context, r *reservationModel.Reservation, accessURL string) {
+	msg, err := renderGuestAccess(r, accessURL, d.location)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to render guest access notification (reservation: %d): %v", r.ID, err)
+		return
+	}
+	if msg.To == "" {
+		return
+	}
+	d.Dispatch(msg)
+}
+
+func (d *Dispatcher) send(ctx context.Context, msg Message) {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	if err := d.notifier.Send(ctx, msg); err != nil {
+		utils.ErrorLogger.Printf("Failed to send notification to %s: %v", msg.To, err)
+	}
+}
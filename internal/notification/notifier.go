@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/didanslmn/movie-reservation-system.git/config"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier mengirim satu pesan ke user, misalnya lewat email.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// NewNotifier memilih implementasi sesuai NOTIFY_DRIVER.
+func NewNotifier(cfg config.NotificationConfig) (Notifier, error) {
+	switch cfg.Driver {
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			return nil, fmt.Errorf("SMTP_HOST is required for smtp notifier")
+		}
+		return NewSMTPNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom), nil
+	case "log":
+		return NewLogNotifier(cfg.LogFile)
+	default:
+		return nil, fmt.Errorf("unknown notification driver %q", cfg.Driver)
+	}
+}
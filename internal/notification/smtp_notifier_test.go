@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"bufio"
+	"context"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpMessage adalah email yang diterima stub SMTP.
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// startSMTPStub menjalankan server SMTP minimal di port acak untuk satu
+// koneksi. rejectRcpt membuat server menolak RCPT TO dengan 550.
+func startSMTPStub(t *testing.T, rejectRcpt bool) (string, int, <-chan smtpMessage) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		tp := textproto.NewConn(conn)
+		var msg smtpMessage
+		tp.PrintfLine("220 localhost ESMTP stub")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				msg.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+				tp.PrintfLine("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				if rejectRcpt {
+					tp.PrintfLine("550 mailbox unavailable")
+					continue
+				}
+				msg.to = append(msg.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+				tp.PrintfLine("250 OK")
+			case cmd == "DATA":
+				tp.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				msg.data = string(data)
+				tp.PrintfLine("250 OK")
+				received <- msg
+			case cmd == "QUIT":
+				tp.PrintfLine("221 bye")
+				return
+			default:
+				tp.PrintfLine("502 command not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p, received
+}
+
+func TestSMTPNotifierSend(t *testing.T) {
+	host, port, received := startSMTPStub(t, false)
+	notifier := NewSMTPNotifier(host, port, "", "", "no-reply@movie.test")
+
+	err := notifier.Send(context.Background(), Message{
+		To:      "budi@example.com",
+		Subject: "Reservasi #12 dikonfirmasi – Studio 1",
+		Body:    "Halo Budi,\nreservation kamu sudah dikonfirmasi.\n",
+	})
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	var got smtpMessage
+	select {
+	case got = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stub did not receive a message")
+	}
+	if got.from != "no-reply@movie.test" {
+		t.Errorf("MAIL FROM = %q, want no-reply@movie.test", got.from)
+	}
+	if len(got.to) != 1 || got.to[0] != "budi@example.com" {
+		t.Errorf("RCPT TO = %v, want [budi@example.com]", got.to)
+	}
+
+	parsed, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(got.data)))
+	if err != nil {
+		t.Fatalf("failed to parse delivered message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("failed to decode subject: %v", err)
+	}
+	if subject != "Reservasi #12 dikonfirmasi – Studio 1" {
+		t.Errorf("Subject = %q", subject)
+	}
+	if to := parsed.Header.Get("To"); to != "budi@example.com" {
+		t.Errorf("To = %q", to)
+	}
+	if ct := parsed.Header.Get("Content-Type"); ct != "text/plain; charset=UTF-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if _, err := parsed.Header.Date(); err != nil {
+		t.Errorf("invalid Date header: %v", err)
+	}
+	var body strings.Builder
+	if _, err := bufio.NewReader(parsed.Body).WriteTo(&body); err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	if want := "Halo Budi,\nreservation kamu sudah dikonfirmasi.\n"; body.String() != want {
+		t.Errorf("body = %q, want %q", body.String(), want)
+	}
+}
+
+func TestSMTPNotifierSendRejected(t *testing.T) {
+	host, port, _ := startSMTPStub(t, true)
+	notifier := NewSMTPNotifier(host, port, "", "", "no-reply@movie.test")
+
+	err := notifier.Send(context.Background(), Message{To: "budi@example.com", Subject: "Test", Body: "test"})
+	if err == nil || !strings.Contains(err.Error(), "550") {
+		t.Fatalf("Send error = %v, want 550 rejection", err)
+	}
+}
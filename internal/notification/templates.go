@@ -0,0 +1,263 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+)
+
+// ReservationData adalah data yang tersedia di template notifikasi reservation.
+type ReservationData struct {
+	ReservationID uint
+	UserName      string
+	MovieTitle    string
+	HallName      string
+	StartTime     time.Time
+	Seats         string
+	ExpiredAt     time.Time
+	CancelReason  string
+	// jadwal mulai sebelum showtime dijadwalkan ulang
+	PreviousStartTime time.Time
+	// magic link reservation guest checkout
+	AccessURL string
+}
+
+type messageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+// waktu di data template sudah dikonversi ke zona waktu bioskop, sehingga
+// datetime cukup menambahkan singkatan zonanya
+var funcs = template.FuncMap{
+	"datetime": func(t time.Time) string { return t.Format("Mon, 02 Jan 2006 15:04 MST") },
+}
+
+func mustTemplate(subject, body string) messageTemplate {
+	return messageTemplate{
+		subject: template.Must(template.New("subject").Funcs(funcs).Parse(subject)),
+		body:    template.Must(template.New("body").Funcs(funcs).Parse(body)),
+	}
+}
+
+// reservationTemplates dipilih berdasarkan status reservation. Status lain
+// (checked_in, completed, no_show) tidak mengirim notifikasi.
+var reservationTemplates = map[string]messageTemplate{
+	reservationModel.StatusPending: mustTemplate(
+		"Seats held: {{.MovieTitle}}",
+		`Hi {{.UserName}},
+
+We are holding your seats for {{.MovieTitle}}.
+
+Booking:  #{{.ReservationID}}
+Hall:     {{.HallName}}
+Showtime: {{datetime .StartTime}}
+Seats:    {{.Seats}}
+
+Please confirm before {{datetime .ExpiredAt}}, otherwise the seats will be released.
+`),
+	reservationModel.StatusConfirmed: mustTemplate(
+		"Booking confirmed: {{.MovieTitle}}",
+		`Hi {{.UserName}},
+
+Your booking is confirmed. Enjoy the movie!
+
+Booking:  #{{.ReservationID}}
+Movie:    {{.MovieTitle}}
+Hall:     {{.HallName}}
+Showtime: {{datetime .StartTime}}
+Seats:    {{.Seats}}
+
+Your ticket is available in the app.
+`),
+	reservationModel.StatusCancelled: mustTemplate(
+		"Booking cancelled: {{.MovieTitle}}",
+		`Hi {{.UserName}},
+
+Your booking #{{.ReservationID}} for {{.MovieTitle}} on {{datetime .StartTime}} has been cancelled.
+{{if .CancelReason}}
+Reason: {{.CancelReason}}
+{{end}}
+Seats {{.Seats}} have been released.
+`),
+	reservationModel.StatusExpired: mustTemplate(
+		"Seat hold expired: {{.MovieTitle}}",
+		`Hi {{.UserName}},
+
+Your hold on seats {{.Seats}} for {{.MovieTitle}} on {{datetime .StartTime}} expired before it was confirmed, so the seats have been released.
+
+Booking #{{.ReservationID}} is no longer valid. You are welcome to book again.
+`),
+	reservationModel.StatusRefunded: mustTemplate(
+		"Booking refunded: {{.MovieTitle}}",
+		`Hi {{.UserName}},
+
+Your booking #{{.ReservationID}} for {{.MovieTitle}} on {{datetime .StartTime}} has been refunded.
+{{if .CancelReason}}
+Reason: {{.CancelReason}}
+{{end}}
+No further action is needed on your side.
+`),
+}
+
+// rescheduleTemplate dikirim saat admin mengubah jadwal showtime yang sudah dibooking.
+var rescheduleTemplate = mustTemplate(
+	"Showtime changed: {{.MovieTitle}}",
+	`Hi {{.UserName}},
+
+The showtime for your booking #{{.ReservationID}} has been rescheduled.
+
+Movie:    {{.MovieTitle}}
+Hall:     {{.HallName}}
+Was:      {{datetime .PreviousStartTime}}
+Now:      {{datetime .StartTime}}
+Seats:    {{.Seats}}
+
+Please let us know in the app whether you accept the new time or would like to cancel with a full refund.
+`)
+
+// waitlistOfferTemplate dikirim saat seat ditahan untuk entry waitlist.
+var waitlistOfferTemplate = mustTemplate(
+	"Seats available: {{.MovieTitle}}",
+	`Hi {{.UserName}},
+
+Good news, seats opened up for {{.MovieTitle}} and we are holding them for you from the waitlist.
+
+Booking:  #{{.ReservationID}}
+Hall:     {{.HallName}}
+Showtime: {{datetime .StartTime}}
+Seats:    {{.Seats}}
+
+Please confirm before {{datetime .ExpiredAt}}, otherwise the seats will be offered to the next person on the waitlist.
+`)
+
+// guestAccessTemplate berisi magic link untuk guest yang memesan tanpa akun.
+var guestAccessTemplate = mustTemplate(
+	"Your booking link: {{.MovieTitle}}",
+	`Hi {{.UserName}},
+
+Use this link to view, confirm, or cancel booking #{{.ReservationID}} for {{.MovieTitle}} on {{datetime .StartTime}}:
+
+{{.AccessURL}}
+
+Keep this link private, anyone who has it can manage the booking. Register with this email address to see the booking in your account.
+`)
+
+// transferTemplate dikirim ke penerima transfer reservation.
+var transferTemplate = mustTemplate(
+	"{{.FromName}} sent you tickets: {{.MovieTitle}}",
+	`Hi {{.UserName}},
+
+{{.FromName}} wants to transfer tickets for {{.MovieTitle}} to you.
+
+Showtime: {{datetime .StartTime}}
+Seats:    {{.Seats}}
+
+Open the app to accept or decline transfer #{{.TransferID}}. The seats become yours only after you accept.
+`)
+
+// TransferData adalah data yang tersedia di template transfer.
+type TransferData struct {
+	TransferID uint
+	UserName   string
+	FromName   string
+	MovieTitle string
+	StartTime  time.Time
+	Seats      string
+}
+
+// renderReservation membuat pesan untuk status reservation saat ini.
+// ok bernilai false jika status tersebut tidak punya template.
+func renderReservation(r *reservationModel.Reservation, loc *time.Location) (msg Message, ok bool, err error) {
+	tmpl, ok := reservationTemplates[r.Status]
+	if !ok {
+		return Message{}, false, nil
+	}
+	msg, err = tmpl.render(r, loc)
+	return msg, true, err
+}
+
+// renderReschedule membuat pesan perubahan jadwal showtime.
+func renderReschedule(r *reservationModel.Reservation, loc *time.Location) (Message, error) {
+	return rescheduleTemplate.render(r, loc)
+}
+
+// renderWaitlistOffer membuat pesan tawaran seat dari waitlist.
+func renderWaitlistOffer(r *reservationModel.Reservation, loc *time.Location) (Message, error) {
+	return waitlistOfferTemplate.render(r, loc)
+}
+
+// renderGuestAccess membuat pesan magic link untuk reservation guest.
+func renderGuestAccess(r *reservationModel.Reservation, accessURL string, loc *time.Location) (Message, error) {
+	data := toReservationData(r, loc)
+	data.AccessURL = accessURL
+	return guestAccessTemplate.execute(r.User.Email, data)
+}
+
+// renderTransfer membuat pesan untuk penerima transfer; hanya seat yang
+// dipindahkan yang dicantumkan.
+func renderTransfer(t *reservationModel.ReservationTransfer, loc *time.Location) (Message, error) {
+	moved := t.Seats()
+	seats := make([]string, 0, len(t.Reservation.Seats))
+	for _, seat := range t.Reservation.Seats {
+		if len(moved) > 0 && !slices.Contains(moved, seat.ID) {
+			continue
+		}
+		seats = append(seats, seat.SeatNumber)
+	}
+	sort.Strings(seats)
+
+	return transferTemplate.execute(t.ToUser.Email, TransferData{
+		TransferID: t.ID,
+		UserName:   t.ToUser.Name,
+		FromName:   t.FromUser.Name,
+		MovieTitle: t.Reservation.Showtime.Movie.Title,
+		StartTime:  t.Reservation.Showtime.StartTime.In(loc),
+		Seats:      strings.Join(seats, ", "),
+	})
+}
+
+func (t messageTemplate) render(r *reservationModel.Reservation, loc *time.Location) (Message, error) {
+	return t.execute(r.User.Email, toReservationData(r, loc))
+}
+
+func (t messageTemplate) execute(to string, data any) (Message, error) {
+	var subject, body bytes.Buffer
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render subject: %w", err)
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return Message{}, fmt.Errorf("failed to render body: %w", err)
+	}
+	return Message{To: to, Subject: subject.String(), Body: body.String()}, nil
+}
+
+// toReservationData menyiapkan data template dengan waktu di zona loc.
+func toReservationData(r *reservationModel.Reservation, loc *time.Location) ReservationData {
+	seats := make([]string, 0, len(r.Seats))
+	for _, seat := range r.Seats {
+		seats = append(seats, seat.SeatNumber)
+	}
+	sort.Strings(seats)
+
+	data := ReservationData{
+		ReservationID: r.ID,
+		UserName:      r.User.Name,
+		MovieTitle:    r.Showtime.Movie.Title,
+		HallName:      r.Showtime.CinemaHall.Name,
+		StartTime:     r.Showtime.StartTime.In(loc),
+		Seats:         strings.Join(seats, ", "),
+		ExpiredAt:     r.ExpiredAt.In(loc),
+		CancelReason:  r.CancelReason,
+	}
+	if r.RescheduledFrom != nil {
+		data.PreviousStartTime = r.RescheduledFrom.In(loc)
+	}
+	return data
+}
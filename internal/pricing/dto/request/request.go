@@ -0,0 +1,16 @@
+package request
+
+// PriceRuleRequest dipakai untuk membuat dan mengubah price rule. Filter yang
+// dikosongkan berlaku untuk semua seat, jenis tiket, hari, atau jam.
+type PriceRuleRequest struct {
+	Name         string   `json:"name" binding:"required,max=100"`
+	SeatCategory string   `json:"seat_category" binding:"max=30"`
+	TicketType   string   `json:"ticket_type" binding:"omitempty,oneof=adult child student senior"`
+	Days         []string `json:"days" binding:"omitempty,dive,oneof=sun mon tue wed thu fri sat"`
+	StartTime    string   `json:"start_time" binding:"omitempty,datetime=15:04"`
+	EndTime      string   `json:"end_time" binding:"omitempty,datetime=15:04"`
+	Percent      int      `json:"percent" binding:"min=-100,max=1000"`
+	Amount       int64    `json:"amount"`
+	Priority     int      `json:"priority"`
+	Active       *bool    `json:"active"`
+}
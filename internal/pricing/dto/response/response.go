@@ -0,0 +1,41 @@
+package response
+
+import "time"
+
+type PriceRuleResponse struct {
+	ID           uint      `json:"id"`
+	Name         string    `json:"name"`
+	SeatCategory string    `json:"seat_category,omitempty"`
+	TicketType   string    `json:"ticket_type,omitempty"`
+	Days         []string  `json:"days"`
+	StartTime    string    `json:"start_time,omitempty"`
+	EndTime      string    `json:"end_time,omitempty"`
+	Percent      int       `json:"percent"`
+	Amount       int64     `json:"amount"`
+	Priority     int       `json:"priority"`
+	Active       bool      `json:"active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// ShowtimePricesResponse adalah daftar harga showtime untuk setiap kategori
+// seat di hall dan setiap jenis tiket.
+type ShowtimePricesResponse struct {
+	ShowtimeID uint            `json:"showtime_id"`
+	StartTime  time.Time       `json:"start_time"`
+	BasePrice  int64           `json:"base_price"`
+	Prices     []PriceResponse `json:"prices"`
+}
+
+type PriceResponse struct {
+	SeatCategory string               `json:"seat_category"`
+	TicketType   string               `json:"ticket_type"`
+	BasePrice    int64                `json:"base_price"`
+	Adjustments  []AdjustmentResponse `json:"adjustments"`
+	Price        int64                `json:"price"`
+}
+
+type AdjustmentResponse struct {
+	Name   string `json:"name"`
+	Amount int64  `json:"amount"`
+}
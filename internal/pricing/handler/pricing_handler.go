@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/repository"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/service"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type PricingHandler struct {
+	service service.PricingService
+}
+
+func NewPricingHandler(service service.PricingService) *PricingHandler {
+	return &PricingHandler{service: service}
+}
+
+func (h *PricingHandler) CreateRule(c *gin.Context) {
+	var req request.PriceRuleRequest
+	if ok := utils.BindAndValidate(c, &req); !ok {
+		return
+	}
+
+	res, err := h.service.CreateRule(c.Request.Context(), req)
+	if err != nil {
+		respondPricingError(c, "Failed to create price rule", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Price rule created successfully", res)
+}
+
+func (h *PricingHandler) GetAllRules(c *gin.Context) {
+	res, err := h.service.GetAllRules(c.Request.Context())
+	if err != nil {
+		respondPricingError(c, "Failed to fetch price rules", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Price rules fetched successfully", res)
+}
+
+func (h *PricingHandler) UpdateRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid price rule ID", err)
+		return
+	}
+
+	var req request.PriceRuleRequest
+	if ok := utils.BindAndValidate(c, &req); !ok {
+		return
+	}
+
+	res, err := h.service.UpdateRule(c.Request.Context(), uint(id), req)
+	if err != nil {
+		respondPricingError(c, "Failed to update price rule", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Price rule updated successfully", res)
+}
+
+func (h *PricingHandler) DeleteRule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid price rule ID", err)
+		return
+	}
+
+	if err := h.service.DeleteRule(c.Request.Context(), uint(id)); err != nil {
+		respondPricingError(c, "Failed to delete price rule", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Price rule deleted successfully", nil)
+}
+
+func (h *PricingHandler) GetShowtimePrices(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid showtime ID", err)
+		return
+	}
+
+	res, err := h.service.GetShowtimePrices(c.Request.Context(), uint(id))
+	if err != nil {
+		respondPricingError(c, "Failed to fetch showtime prices", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Showtime prices fetched successfully", res)
+}
+
+func respondPricingError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrRuleNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, "Price rule not found", err)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, "Showtime not found", err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, msg, err)
+	}
+}
@@ -0,0 +1,70 @@
+package mapper
+
+import (
+	"strings"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/model"
+)
+
+// ApplyPriceRuleRequest mengisi rule dari request. Active yang tidak dikirim
+// tidak mengubah status rule.
+func ApplyPriceRuleRequest(rule *model.PriceRule, req request.PriceRuleRequest) {
+	rule.Name = req.Name
+	rule.SeatCategory = req.SeatCategory
+	rule.TicketType = req.TicketType
+	rule.Days = strings.Join(req.Days, ",")
+	rule.StartTime = req.StartTime
+	rule.EndTime = req.EndTime
+	rule.Percent = req.Percent
+	rule.Amount = req.Amount
+	rule.Priority = req.Priority
+	if req.Active != nil {
+		rule.Active = *req.Active
+	}
+}
+
+func ToPriceRuleResponse(rule *model.PriceRule) *response.PriceRuleResponse {
+	days := []string{}
+	if rule.Days != "" {
+		days = strings.Split(rule.Days, ",")
+	}
+	return &response.PriceRuleResponse{
+		ID:           rule.ID,
+		Name:         rule.Name,
+		SeatCategory: rule.SeatCategory,
+		TicketType:   rule.TicketType,
+		Days:         days,
+		StartTime:    rule.StartTime,
+		EndTime:      rule.EndTime,
+		Percent:      rule.Percent,
+		Amount:       rule.Amount,
+		Priority:     rule.Priority,
+		Active:       rule.Active,
+		CreatedAt:    rule.CreatedAt,
+		UpdatedAt:    rule.UpdatedAt,
+	}
+}
+
+func ToPriceRuleResponseList(rules []model.PriceRule) []response.PriceRuleResponse {
+	responses := make([]response.PriceRuleResponse, 0, len(rules))
+	for i := range rules {
+		responses = append(responses, *ToPriceRuleResponse(&rules[i]))
+	}
+	return responses
+}
+
+func ToPriceResponse(q model.Quote) response.PriceResponse {
+	adjustments := make([]response.AdjustmentResponse, 0, len(q.Adjustments))
+	for _, a := range q.Adjustments {
+		adjustments = append(adjustments, response.AdjustmentResponse{Name: a.Name, Amount: a.Amount})
+	}
+	return response.PriceResponse{
+		SeatCategory: q.SeatCategory,
+		TicketType:   q.TicketType,
+		BasePrice:    q.BasePrice,
+		Adjustments:  adjustments,
+		Price:        q.Price,
+	}
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Weekdays adalah kode hari yang dipakai PriceRule.Days, diindeks dengan time.Weekday.
+var Weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// PriceRule menyesuaikan harga tiket. Semua filter yang diisi harus cocok;
+// filter kosong berarti berlaku untuk semua. Rule dengan Priority lebih kecil
+// diterapkan lebih dulu.
+type PriceRule struct {
+	ID   uint   `gorm:"primaryKey"`
+	Name string `gorm:"type:varchar(100);not null"`
+
+	SeatCategory string `gorm:"type:varchar(30)"`
+	TicketType   string `gorm:"type:varchar(20)"`
+	// Days berisi kode hari dipisah koma, misalnya "sat,sun"
+	Days string `gorm:"type:varchar(40)"`
+	// StartTime dan EndTime dalam format HH:MM; jendela boleh melewati tengah malam
+	StartTime string `gorm:"type:varchar(5)"`
+	EndTime   string `gorm:"type:varchar(5)"`
+
+	// penyesuaian: persen dari harga saat ini, lalu ditambah nominal tetap
+	Percent  int   `gorm:"not null"`
+	Amount   int64 `gorm:"not null"`
+	Priority int   `gorm:"not null"`
+	Active   bool  `gorm:"not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CategoryRule membuat rule dari modifier harga kategori seat. Rule ini tidak
+// disimpan dan selalu diterapkan sebelum price rule lain.
+func CategoryRule(category string, percent int, amount int64) PriceRule {
+	return PriceRule{
+		Name:         "category " + category,
+		SeatCategory: category,
+		Percent:      percent,
+		Amount:       amount,
+		Active:       true,
+	}
+}
+
+// Matches memeriksa apakah rule berlaku untuk seat, jenis tiket, dan waktu mulai showtime.
+func (r *PriceRule) Matches(seatCategory, ticketType string, at time.Time) bool {
+	if !r.Active {
+		return false
+	}
+	if r.SeatCategory != "" && r.SeatCategory != seatCategory {
+		return false
+	}
+	if r.TicketType != "" && r.TicketType != ticketType {
+		return false
+	}
+	if r.Days != "" && !slices.Contains(strings.Split(r.Days, ","), Weekdays[at.Weekday()]) {
+		return false
+	}
+	if r.StartTime == "" && r.EndTime == "" {
+		return true
+	}
+
+	minute := at.Hour()*60 + at.Minute()
+	start, end := 0, 24*60
+	if r.StartTime != "" {
+		start = clockMinutes(r.StartTime)
+	}
+	if r.EndTime != "" {
+		end = clockMinutes(r.EndTime)
+	}
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// clockMinutes mengubah "HH:MM" menjadi menit sejak tengah malam.
+func clockMinutes(clock string) int {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0
+	}
+	return t.Hour()*60 + t.Minute()
+}
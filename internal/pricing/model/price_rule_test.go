@@ -0,0 +1,49 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+// at mengembalikan waktu pada 6-8 Januari 2024 (Sabtu sampai Senin).
+func at(day, hour, minute int) time.Time {
+	return time.Date(2024, time.January, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestPriceRuleMatches(t *testing.T) {
+	const saturday, sunday, monday = 6, 7, 8
+
+	tests := []struct {
+		name         string
+		rule         PriceRule
+		seatCategory string
+		ticketType   string
+		at           time.Time
+		want         bool
+	}{
+		{name: "no filters", rule: PriceRule{Active: true}, at: at(monday, 10, 0), want: true},
+		{name: "inactive rule", rule: PriceRule{}, at: at(monday, 10, 0), want: false},
+		{name: "seat category matches", rule: PriceRule{Active: true, SeatCategory: "premium"}, seatCategory: "premium", at: at(monday, 10, 0), want: true},
+		{name: "seat category differs", rule: PriceRule{Active: true, SeatCategory: "premium"}, seatCategory: "standard", at: at(monday, 10, 0), want: false},
+		{name: "ticket type differs", rule: PriceRule{Active: true, TicketType: TicketChild}, ticketType: TicketAdult, at: at(monday, 10, 0), want: false},
+		{name: "weekend rule on saturday", rule: PriceRule{Active: true, Days: "sat,sun"}, at: at(saturday, 10, 0), want: true},
+		{name: "weekend rule on sunday", rule: PriceRule{Active: true, Days: "sat,sun"}, at: at(sunday, 23, 59), want: true},
+		{name: "weekend rule on monday", rule: PriceRule{Active: true, Days: "sat,sun"}, at: at(monday, 0, 0), want: false},
+		{name: "window start is inclusive", rule: PriceRule{Active: true, StartTime: "10:00", EndTime: "14:00"}, at: at(monday, 10, 0), want: true},
+		{name: "window end is exclusive", rule: PriceRule{Active: true, StartTime: "10:00", EndTime: "14:00"}, at: at(monday, 14, 0), want: false},
+		{name: "only start time", rule: PriceRule{Active: true, StartTime: "18:00"}, at: at(monday, 21, 30), want: true},
+		{name: "only end time", rule: PriceRule{Active: true, EndTime: "12:00"}, at: at(monday, 12, 30), want: false},
+		{name: "midnight window before midnight", rule: PriceRule{Active: true, StartTime: "22:00", EndTime: "02:00"}, at: at(monday, 23, 15), want: true},
+		{name: "midnight window after midnight", rule: PriceRule{Active: true, StartTime: "22:00", EndTime: "02:00"}, at: at(monday, 1, 59), want: true},
+		{name: "midnight window at end", rule: PriceRule{Active: true, StartTime: "22:00", EndTime: "02:00"}, at: at(monday, 2, 0), want: false},
+		{name: "midnight window during the day", rule: PriceRule{Active: true, StartTime: "22:00", EndTime: "02:00"}, at: at(monday, 12, 0), want: false},
+		{name: "all filters match", rule: PriceRule{Active: true, SeatCategory: "couple", TicketType: TicketStudent, Days: "mon", StartTime: "09:00", EndTime: "17:00"}, seatCategory: "couple", ticketType: TicketStudent, at: at(monday, 13, 0), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.seatCategory, tt.ticketType, tt.at); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Adjustment adalah satu penyesuaian harga dari sebuah rule.
+type Adjustment struct {
+	Name   string `json:"name"`
+	Amount int64  `json:"amount"`
+}
+
+// Breakdown adalah rincian harga satu tiket; disimpan bersama seat yang
+// dipesan agar harga reservation tidak berubah saat rule diubah.
+type Breakdown struct {
+	BasePrice   int64        `json:"base_price"`
+	Adjustments []Adjustment `json:"adjustments"`
+}
+
+func (b Breakdown) String() string {
+	data, _ := json.Marshal(b)
+	return string(data)
+}
+
+// Quote adalah harga satu seat untuk satu jenis tiket. Harga dalam satuan
+// mata uang terkecil.
+type Quote struct {
+	SeatID       uint
+	SeatCategory string
+	TicketType   string
+	Breakdown
+	Price int64
+}
+
+// Calculate menghitung harga dari base price dengan menerapkan rule yang cocok
+// sesuai urutan. Harga tidak pernah di bawah nol.
+func Calculate(basePrice int64, seatCategory, ticketType string, at time.Time, rules []PriceRule) Quote {
+	quote := Quote{
+		SeatCategory: seatCategory,
+		TicketType:   ticketType,
+		Breakdown:    Breakdown{BasePrice: basePrice, Adjustments: []Adjustment{}},
+		Price:        basePrice,
+	}
+	for i := range rules {
+		if !rules[i].Matches(seatCategory, ticketType, at) {
+			continue
+		}
+		quote.apply(rules[i].Name, quote.Price*int64(rules[i].Percent)/100+rules[i].Amount)
+	}
+	return quote
+}
+
+func (q *Quote) apply(name string, amount int64) {
+	if q.Price+amount < 0 {
+		amount = -q.Price
+	}
+	q.Price += amount
+	q.Adjustments = append(q.Adjustments, Adjustment{Name: name, Amount: amount})
+}
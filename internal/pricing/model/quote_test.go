@@ -0,0 +1,84 @@
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCalculate(t *testing.T) {
+	monday := at(8, 19, 0)
+
+	tests := []struct {
+		name            string
+		basePrice       int64
+		rules           []PriceRule
+		want            int64
+		wantAdjustments []Adjustment
+	}{
+		{
+			name:            "no rules",
+			basePrice:       50000,
+			want:            50000,
+			wantAdjustments: []Adjustment{},
+		},
+		{
+			name:            "percent and amount in one rule",
+			basePrice:       50000,
+			rules:           []PriceRule{{Name: "evening", Active: true, Percent: 10, Amount: 2000}},
+			want:            57000,
+			wantAdjustments: []Adjustment{{Name: "evening", Amount: 7000}},
+		},
+		{
+			name:      "rules applied in order on the running price",
+			basePrice: 40000,
+			rules: []PriceRule{
+				CategoryRule("couple", 100, 0),
+				{Name: "student", Active: true, Percent: -25},
+			},
+			want:            60000,
+			wantAdjustments: []Adjustment{{Name: "category couple", Amount: 40000}, {Name: "student", Amount: -20000}},
+		},
+		{
+			name:      "non-matching rules are skipped",
+			basePrice: 50000,
+			rules: []PriceRule{
+				{Name: "weekend", Active: true, Days: "sat,sun", Percent: 20},
+				{Name: "child", Active: true, TicketType: TicketChild, Amount: -10000},
+				{Name: "disabled", Amount: 5000},
+			},
+			want:            50000,
+			wantAdjustments: []Adjustment{},
+		},
+		{
+			name:            "price is clamped at zero",
+			basePrice:       30000,
+			rules:           []PriceRule{{Name: "free promo", Active: true, Amount: -50000}},
+			want:            0,
+			wantAdjustments: []Adjustment{{Name: "free promo", Amount: -30000}},
+		},
+		{
+			name:      "rules after the clamp start from zero",
+			basePrice: 30000,
+			rules: []PriceRule{
+				{Name: "free promo", Active: true, Percent: -100, Amount: -1000},
+				{Name: "surcharge", Active: true, Percent: 50, Amount: 2000},
+			},
+			want:            2000,
+			wantAdjustments: []Adjustment{{Name: "free promo", Amount: -30000}, {Name: "surcharge", Amount: 2000}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := Calculate(tt.basePrice, "couple", TicketAdult, monday, tt.rules)
+			if quote.Price != tt.want {
+				t.Errorf("Price = %d, want %d", quote.Price, tt.want)
+			}
+			if quote.BasePrice != tt.basePrice {
+				t.Errorf("BasePrice = %d, want %d", quote.BasePrice, tt.basePrice)
+			}
+			if !slices.Equal(quote.Adjustments, tt.wantAdjustments) {
+				t.Errorf("Adjustments = %+v, want %+v", quote.Adjustments, tt.wantAdjustments)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+import "slices"
+
+// Jenis tiket per seat. Selisih harga antar jenis diatur lewat price rule.
+const (
+	TicketAdult   = "adult"
+	TicketChild   = "child"
+	TicketStudent = "student"
+	TicketSenior  = "senior"
+)
+
+// TicketTypes adalah semua jenis tiket, adult menjadi default.
+var TicketTypes = []string{TicketAdult, TicketChild, TicketStudent, TicketSenior}
+
+func IsTicketType(t string) bool {
+	return slices.Contains(TicketTypes, t)
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+)
+
+type PriceRuleRepository interface {
+	Create(ctx context.Context, rule *model.PriceRule) error
+	GetByID(ctx context.Context, id uint) (*model.PriceRule, error)
+	GetAll(ctx context.Context) ([]model.PriceRule, error)
+	GetActive(ctx context.Context) ([]model.PriceRule, error)
+	Update(ctx context.Context, rule *model.PriceRule) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type priceRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewPriceRuleRepository(db *gorm.DB) PriceRuleRepository {
+	return &priceRuleRepository{db: db}
+}
+
+var ErrRuleNotFound = errors.New("price rule not found")
+
+func (r *priceRuleRepository) Create(ctx context.Context, rule *model.PriceRule) error {
+	if err := r.db.WithContext(ctx).Create(rule).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to create price rule %q: %v", rule.Name, err)
+		return fmt.Errorf("failed to create price rule: %w", err)
+	}
+	return nil
+}
+
+func (r *priceRuleRepository) GetByID(ctx context.Context, id uint) (*model.PriceRule, error) {
+	var rule model.PriceRule
+	if err := r.db.WithContext(ctx).First(&rule, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRuleNotFound
+		}
+		utils.ErrorLogger.Printf("Error to get price rule by ID %d: %v", id, err)
+		return nil, fmt.Errorf("failed to get price rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// GetAll mengembalikan rule sesuai urutan penerapan.
+func (r *priceRuleRepository) GetAll(ctx context.Context) ([]model.PriceRule, error) {
+	var rules []model.PriceRule
+	if err := r.db.WithContext(ctx).Order("priority, id").Find(&rules).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to get price rules: %v", err)
+		return nil, fmt.Errorf("failed to get price rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *priceRuleRepository) GetActive(ctx context.Context) ([]model.PriceRule, error) {
+	var rules []model.PriceRule
+	if err := r.db.WithContext(ctx).Where("active = ?", true).Order("priority, id").Find(&rules).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to get active price rules: %v", err)
+		return nil, fmt.Errorf("failed to get price rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *priceRuleRepository) Update(ctx context.Context, rule *model.PriceRule) error {
+	if err := r.db.WithContext(ctx).Save(rule).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to update price rule (ID: %d): %v", rule.ID, err)
+		return fmt.Errorf("failed to update price rule: %w", err)
+	}
+	return nil
+}
+
+func (r *priceRuleRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&model.PriceRule{}, id)
+	if result.Error != nil {
+		utils.ErrorLogger.Printf("Error to delete price rule (ID: %d): %v", id, result.Error)
+		return fmt.Errorf("failed to delete price rule: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRuleNotFound
+	}
+	return nil
+}
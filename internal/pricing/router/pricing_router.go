@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/handler"
+	"github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/gin-gonic/gin"
+)
+
+func PricingRoutes(rg *gin.RouterGroup, h *handler.PricingHandler, jwtSecret string) {
+	// Hanya Admin yang dapat mengatur price rule
+	rules := rg.Group("/pricing/rules")
+	rules.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	rules.Use(middleware.RoleBasedAccess(model.RoleAdmin))
+	{
+		rules.GET("/", h.GetAllRules)
+		rules.POST("/", h.CreateRule)
+		rules.PUT("/:id", h.UpdateRule)
+		rules.DELETE("/:id", h.DeleteRule)
+	}
+
+	// User & Admin dapat melihat daftar harga showtime
+	showtimes := rg.Group("/showtimes")
+	showtimes.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	showtimes.Use(middleware.RoleBasedAccess(model.RoleUser, model.RoleAdmin))
+	{
+		showtimes.GET("/:id/prices", h.GetShowtimePrices)
+	}
+}
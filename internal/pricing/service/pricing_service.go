@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/config"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/pricing/repository"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	seatRepository "github.com/didanslmn/movie-reservation-system.git/internal/seat/repository"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+	showtimeRepository "github.com/didanslmn/movie-reservation-system.git/internal/showtime/repository"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+)
+
+var ErrInvalidTicketType = errors.New("invalid ticket type")
+
+type PricingService interface {
+	CreateRule(ctx context.Context, req request.PriceRuleRequest) (*response.PriceRuleResponse, error)
+	GetAllRules(ctx context.Context) ([]response.PriceRuleResponse, error)
+	UpdateRule(ctx context.Context, id uint, req request.PriceRuleRequest) (*response.PriceRuleResponse, error)
+	DeleteRule(ctx context.Context, id uint) error
+	GetShowtimePrices(ctx context.Context, showtimeID uint) (*response.ShowtimePricesResponse, error)
+	QuoteSeats(ctx context.Context, showtime *showtimeModel.Showtime, seats []seatModel.Seat, ticketTypes map[uint]string) ([]model.Quote, error)
+}
+
+type pricingService struct {
+	ruleRepo     repository.PriceRuleRepository
+	showtimeRepo showtimeRepository.ShowtimeRepository
+	seatRepo     seatRepository.SeatRepository
+	categoryRepo seatRepository.SeatCategoryRepository
+	// location adalah zona waktu bioskop untuk rule hari dan jam
+	location *time.Location
+}
+
+func NewPricingService(ruleRepo repository.PriceRuleRepository, showtimeRepo showtimeRepository.ShowtimeRepository, seatRepo seatRepository.SeatRepository, categoryRepo seatRepository.SeatCategoryRepository, cfg config.PricingConfig) PricingService {
+	return &pricingService{
+		ruleRepo:     ruleRepo,
+		showtimeRepo: showtimeRepo,
+		seatRepo:     seatRepo,
+		categoryRepo: categoryRepo,
+		location:     cfg.Location,
+	}
+}
+
+func (s *pricingService) CreateRule(ctx context.Context, req request.PriceRuleRequest) (*response.PriceRuleResponse, error) {
+	// rule baru aktif secara default
+	rule := model.PriceRule{Active: true}
+	mapper.ApplyPriceRuleRequest(&rule, req)
+	if err := s.ruleRepo.Create(ctx, &rule); err != nil {
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Price rule created (ID: %d, name: %s)", rule.ID, rule.Name)
+	return mapper.ToPriceRuleResponse(&rule), nil
+}
+
+func (s *pricingService) GetAllRules(ctx context.Context) ([]response.PriceRuleResponse, error) {
+	rules, err := s.ruleRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToPriceRuleResponseList(rules), nil
+}
+
+func (s *pricingService) UpdateRule(ctx context.Context, id uint, req request.PriceRuleRequest) (*response.PriceRuleResponse, error) {
+	rule, err := s.ruleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	mapper.ApplyPriceRuleRequest(rule, req)
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Price rule updated (ID: %d)", rule.ID)
+	return mapper.ToPriceRuleResponse(rule), nil
+}
+
+func (s *pricingService) DeleteRule(ctx context.Context, id uint) error {
+	if err := s.ruleRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	utils.InfoLogger.Printf("Price rule deleted (ID: %d)", id)
+	return nil
+}
+
+// GetShowtimePrices menghitung harga untuk setiap kategori seat yang ada di
+// hall showtime dan setiap jenis tiket.
+func (s *pricingService) GetShowtimePrices(ctx context.Context, showtimeID uint) (*response.ShowtimePricesResponse, error) {
+	showtime, err := s.showtimeRepo.GetByID(ctx, showtimeID)
+	if err != nil {
+		utils.ErrorLogger.Printf("Showtime not found (ID: %d): %v", showtimeID, err)
+		return nil, fmt.Errorf("showtime not found: %w", err)
+	}
+	seats, err := s.seatRepo.GetByHallID(ctx, showtime.CinemaHallID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]string, 0)
+	for _, seat := range seats {
+		if !slices.Contains(categories, seat.Category) {
+			categories = append(categories, seat.Category)
+		}
+	}
+	slices.Sort(categories)
+
+	res := &response.ShowtimePricesResponse{
+		ShowtimeID: showtime.ID,
+		StartTime:  showtime.StartTime,
+		BasePrice:  showtime.BasePrice,
+		Prices:     make([]response.PriceResponse, 0, len(categories)*len(model.TicketTypes)),
+	}
+	for _, category := range categories {
+		for _, ticketType := range model.TicketTypes {
+			quote := model.Calculate(showtime.BasePrice, category, ticketType, s.localStart(showtime), rules)
+			res.Prices = append(res.Prices, mapper.ToPriceResponse(quote))
+		}
+	}
+	return res, nil
+}
+
+// QuoteSeats menghitung harga setiap seat. ticketTypes memetakan seat ID ke
+// jenis tiket; seat yang tidak disebut memakai tiket adult.
+func (s *pricingService) QuoteSeats(ctx context.Context, showtime *showtimeModel.Showtime, seats []seatModel.Seat, ticketTypes map[uint]string) ([]model.Quote, error) {
+	for seatID, ticketType := range ticketTypes {
+		if !model.IsTicketType(ticketType) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidTicketType, ticketType)
+		}
+		if !slices.ContainsFunc(seats, func(seat seatModel.Seat) bool { return seat.ID == seatID }) {
+			return nil, fmt.Errorf("%w: seat %d is not part of the selection", ErrInvalidTicketType, seatID)
+		}
+	}
+
+	rules, err := s.rules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	quotes := make([]model.Quote, 0, len(seats))
+	for _, seat := range seats {
+		ticketType := ticketTypes[seat.ID]
+		if ticketType == "" {
+			ticketType = model.TicketAdult
+		}
+		quote := model.Calculate(showtime.BasePrice, seat.Category, ticketType, s.localStart(showtime), rules)
+		quote.SeatID = seat.ID
+		quotes = append(quotes, quote)
+	}
+	return quotes, nil
+}
+
+// rules mengembalikan rule yang diterapkan berurutan: modifier kategori seat
+// lebih dulu, lalu price rule aktif sesuai prioritas.
+func (s *pricingService) rules(ctx context.Context) ([]model.PriceRule, error) {
+	categories, err := s.categoryRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.ruleRepo.GetActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]model.PriceRule, 0, len(categories)+len(active))
+	for _, c := range categories {
+		if c.PricePercent == 0 && c.PriceAmount == 0 {
+			continue
+		}
+		rules = append(rules, model.CategoryRule(c.Name, c.PricePercent, c.PriceAmount))
+	}
+	return append(rules, active...), nil
+}
+
+// localStart mengembalikan jadwal mulai showtime di zona waktu bioskop
+// (PRICING_TIMEZONE), yang dipakai untuk mencocokkan rule hari dan jam.
+func (s *pricingService) localStart(showtime *showtimeModel.Showtime) time.Time {
+	return showtime.StartTime.In(s.location)
+}
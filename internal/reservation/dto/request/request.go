@@ -1,6 +1,64 @@
 package request
 
+import "time"
+
 type CreateReservationRequest struct {
 	ShowtimeID uint   `json:"showtime_id" binding:"required"`
 	SeatIDs    []uint `json:"seat_id" binding:"required,min=1"`
+	// TicketTypes memetakan seat ID ke jenis tiket (adult, child, student,
+	// senior); seat yang tidak disebut memakai adult
+	TicketTypes map[uint]string `json:"ticket_types,omitempty"`
+}
+
+// UpdateReservationRequest mengganti seat dan/atau memindahkan ke showtime lain
+// dari film yang sama. ShowtimeID kosong berarti tetap di showtime sekarang.
+type UpdateReservationRequest struct {
+	ShowtimeID uint   `json:"showtime_id"`
+	SeatIDs    []uint `json:"seat_id" binding:"required,min=1"`
+	// TicketTypes kosong berarti jenis tiket lama dipertahankan
+	TicketTypes map[uint]string `json:"ticket_types"`
+}
+
+// BestAvailableRequest meminta sistem memilih seat untuk rombongan. Hold true
+// langsung menahan seat terpilih sebagai reservation pending.
+type BestAvailableRequest struct {
+	ShowtimeID uint `json:"showtime_id" binding:"required"`
+	PartySize  int  `json:"party_size" binding:"required,min=1"`
+	Hold       bool `json:"hold"`
+}
+
+// GuestReservationRequest dipakai guest checkout tanpa akun.
+type GuestReservationRequest struct {
+	Name       string `json:"name" binding:"required,max=255"`
+	Email      string `json:"email" binding:"required,email,max=255"`
+	ShowtimeID uint   `json:"showtime_id" binding:"required"`
+	SeatIDs    []uint `json:"seat_id" binding:"required,min=1"`
+
+	TicketTypes map[uint]string `json:"ticket_types"`
+}
+
+// TransferRequest memindahkan reservation ke user terdaftar lain. SeatIDs
+// kosong berarti seluruh seat reservation ikut dipindahkan.
+type TransferRequest struct {
+	Email   string `json:"email" binding:"required,email"`
+	SeatIDs []uint `json:"seat_id"`
+}
+
+type CancelReservationRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+type ReservationFilterRequest struct {
+	UserID uint       `form:"user_id"`
+	Status string     `form:"status" binding:"omitempty,oneof=pending confirmed checked_in expired cancelled refunded completed no_show"`
+	From   *time.Time `form:"from" time_format:"2006-01-02"`
+	To     *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// BookingLimitRequest mengatur override batas booking user. Nilai 0 berarti memakai default.
+type BookingLimitRequest struct {
+	MaxSeatsPerReservation int    `json:"max_seats_per_reservation" binding:"min=0"`
+	MaxSeatsPerShowtime    int    `json:"max_seats_per_showtime" binding:"min=0"`
+	MaxActiveReservations  int    `json:"max_active_reservations" binding:"min=0"`
+	Note                   string `json:"note" binding:"max=255"`
 }
@@ -1,13 +1,94 @@
 package response
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 type ReservationResponse struct {
-	ID       uint             `json:"id"`
-	User     UserResponse     `json:"user"`
-	Showtime ShowtimeResponse `json:"showtime"`
-	Seats    []SeatResponse   `json:"seat"`
-	Status   string           `json:"status"`
+	ID             uint                  `json:"id"`
+	User           UserResponse          `json:"user"`
+	Showtime       ShowtimeResponse      `json:"showtime"`
+	Seats          []SeatResponse        `json:"seat"`
+	Price          PriceResponse         `json:"price"`
+	Status         string                `json:"status"`
+	AllowedActions []string              `json:"allowed_actions"`
+	ExpiredAt      time.Time             `json:"expired_at"`
+	Cancellation   *CancellationResponse `json:"cancellation,omitempty"`
+	Modification   *ModificationResponse `json:"modification,omitempty"`
+	Reschedule     *RescheduleResponse   `json:"reschedule,omitempty"`
+	Warnings       []string              `json:"warnings,omitempty"`
+}
+
+// PriceResponse adalah harga yang dikutip saat seat dipesan.
+type PriceResponse struct {
+	Total int64               `json:"total"`
+	Seats []SeatPriceResponse `json:"seats"`
+}
+
+type SeatPriceResponse struct {
+	SeatID     uint   `json:"seat_id"`
+	TicketType string `json:"ticket_type"`
+	Price      int64  `json:"price"`
+	// Breakdown berisi base_price dan daftar adjustments dari pricing rule
+	Breakdown json.RawMessage `json:"breakdown,omitempty"`
+}
+
+type ModificationResponse struct {
+	ModifiedAt time.Time `json:"modified_at"`
+	Count      int       `json:"count"`
+	Note       string    `json:"note"`
+}
+
+// RescheduleResponse muncul jika jadwal showtime diubah setelah reservation dibuat.
+type RescheduleResponse struct {
+	Status            string    `json:"status"`
+	PreviousStartTime time.Time `json:"previous_start_time"`
+}
+
+type CancellationResponse struct {
+	CancelledAt time.Time `json:"cancelled_at"`
+	CancelledBy uint      `json:"cancelled_by"`
+	Reason      string    `json:"reason,omitempty"`
+}
+
+type HoldResponse struct {
+	ReservationID    uint      `json:"reservation_id"`
+	Status           string    `json:"status"`
+	ExpiredAt        time.Time `json:"expired_at"`
+	RemainingSeconds int64     `json:"remaining_seconds"`
+}
+
+// ReservationEventResponse adalah satu entri audit trail reservation.
+// Actor kosong berarti perubahan dilakukan sistem.
+type ReservationEventResponse struct {
+	ID         uint            `json:"id"`
+	Type       string          `json:"type"`
+	ActorID    *uint           `json:"actor_id"`
+	ActorRole  string          `json:"actor_role,omitempty"`
+	FromStatus string          `json:"from_status,omitempty"`
+	ToStatus   string          `json:"to_status,omitempty"`
+	OldValue   json.RawMessage `json:"old_value,omitempty"`
+	NewValue   json.RawMessage `json:"new_value,omitempty"`
+	Reason     string          `json:"reason,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// TransferResponse adalah permintaan transfer reservation. Reservation hanya
+// diisi setelah transfer diterima, berisi reservation milik penerima.
+type TransferResponse struct {
+	ID               uint                 `json:"id"`
+	ReservationID    uint                 `json:"reservation_id"`
+	From             UserResponse         `json:"from"`
+	To               UserResponse         `json:"to"`
+	MovieTitle       string               `json:"movie_title"`
+	StartTime        time.Time            `json:"start_time"`
+	Seats            []SeatResponse       `json:"seat"`
+	Status           string               `json:"status"`
+	NewReservationID *uint                `json:"new_reservation_id,omitempty"`
+	CreatedAt        time.Time            `json:"created_at"`
+	RespondedAt      *time.Time           `json:"responded_at,omitempty"`
+	Reservation      *ReservationResponse `json:"reservation,omitempty"`
 }
 
 type UserResponse struct {
@@ -21,8 +102,26 @@ type ShowtimeResponse struct {
 	EndTime   time.Time `json:"end_time"`
 }
 
+// BestAvailableResponse berisi seat terpilih; Together false berarti rombongan
+// terpaksa dipecah ke beberapa blok.
+type BestAvailableResponse struct {
+	ShowtimeID  uint                 `json:"showtime_id"`
+	Seats       []SeatResponse       `json:"seat"`
+	Together    bool                 `json:"together"`
+	Reservation *ReservationResponse `json:"reservation,omitempty"`
+}
+
 type SeatResponse struct {
 	ID         uint   `json:"id"`
 	SeatNumber string `json:"seat_number"`
 	Row        string `json:"row"`
 }
+
+type BookingLimitResponse struct {
+	UserID                 uint   `json:"user_id"`
+	MaxSeatsPerReservation int    `json:"max_seats_per_reservation"`
+	MaxSeatsPerShowtime    int    `json:"max_seats_per_showtime"`
+	MaxActiveReservations  int    `json:"max_active_reservations"`
+	Override               bool   `json:"override"`
+	Note                   string `json:"note,omitempty"`
+}
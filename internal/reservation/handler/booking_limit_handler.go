@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type BookingLimitHandler struct {
+	service service.BookingLimitService
+}
+
+func NewBookingLimitHandler(service service.BookingLimitService) *BookingLimitHandler {
+	return &BookingLimitHandler{service: service}
+}
+
+func (h *BookingLimitHandler) GetAllOverrides(c *gin.Context) {
+	res, err := h.service.GetAllOverrides(c.Request.Context())
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch booking limits", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Booking limits fetched successfully", res)
+}
+
+func (h *BookingLimitHandler) GetUserLimit(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	res, err := h.service.GetEffectiveLimit(c.Request.Context(), uint(userID))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch booking limit", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Booking limit fetched successfully", res)
+}
+
+func (h *BookingLimitHandler) SetUserLimit(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req request.BookingLimitRequest
+	if !utils.BindAndValidate(c, &req) {
+		return
+	}
+
+	res, err := h.service.SetOverride(c.Request.Context(), uint(userID), req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Failed to save booking limit", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Booking limit saved successfully", res)
+}
+
+func (h *BookingLimitHandler) DeleteUserLimit(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	if err := h.service.DeleteOverride(c.Request.Context(), uint(userID)); err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete booking limit", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Booking limit deleted successfully", nil)
+}
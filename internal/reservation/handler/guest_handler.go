@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// GuestHandler tidak memakai JWT; token magic link di URL adalah kredensialnya.
+type GuestHandler struct {
+	service service.GuestService
+}
+
+func NewGuestHandler(service service.GuestService) *GuestHandler {
+	return &GuestHandler{service: service}
+}
+
+func (h *GuestHandler) Checkout(c *gin.Context) {
+	var req request.GuestReservationRequest
+	if ok := utils.BindAndValidate(c, &req); !ok {
+		return
+	}
+
+	idempotencyKey := c.GetHeader("Idempotency-Key")
+	if len(idempotencyKey) > 255 {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid Idempotency-Key header", errors.New("idempotency key must be at most 255 characters"))
+		return
+	}
+
+	res, err := h.service.Checkout(c.Request.Context(), &req, idempotencyKey)
+	if err != nil {
+		respondGuestError(c, "Failed to create reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation created, the access link has been sent to your email", res)
+}
+
+func (h *GuestHandler) GetReservation(c *gin.Context) {
+	res, err := h.service.GetReservation(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondGuestError(c, "Failed to fetch reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation fetched successfully", res)
+}
+
+func (h *GuestHandler) ConfirmReservation(c *gin.Context) {
+	res, err := h.service.ConfirmReservation(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondGuestError(c, "Failed to confirm reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation confirmed successfully", res)
+}
+
+func (h *GuestHandler) CancelReservation(c *gin.Context) {
+	// body opsional, hanya berisi alasan pembatalan
+	var req request.CancelReservationRequest
+	if c.Request.ContentLength > 0 && !utils.BindAndValidate(c, &req) {
+		return
+	}
+
+	res, err := h.service.CancelReservation(c.Request.Context(), c.Param("token"), req.Reason)
+	if err != nil {
+		respondGuestError(c, "Failed to cancel reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation cancelled successfully", res)
+}
+
+func respondGuestError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidAccessToken):
+		utils.RespondWithError(c, http.StatusNotFound, "Reservation not found", err)
+	case errors.Is(err, service.ErrGuestEmailRegistered):
+		utils.RespondWithError(c, http.StatusConflict, msg, err)
+	case errors.Is(err, utils.ErrRateLimited):
+		utils.RespondWithError(c, http.StatusTooManyRequests, msg, err)
+	default:
+		respondReservationError(c, msg, err)
+	}
+}
@@ -1,13 +1,21 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	pricing "github.com/didanslmn/movie-reservation-system.git/internal/pricing/service"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
 	"github.com/didanslmn/movie-reservation-system.git/utils"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type ReservationHandler struct {
@@ -36,33 +44,100 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 		return
 	}
 
-	res, err := h.service.CreateReservation(c.Request.Context(), userID, &req)
+	idempotencyKey := c.GetHeader("Idempotency-Key")
+	if len(idempotencyKey) > 255 {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid Idempotency-Key header", errors.New("idempotency key must be at most 255 characters"))
+		return
+	}
+
+	res, err := h.service.CreateReservation(c.Request.Context(), userID, &req, idempotencyKey)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to create reservation", err)
+		respondReservationError(c, "Failed to create reservation", err)
 		return
 	}
 	utils.RespondWithSuccess(c, "Reservation created successfully", res)
 }
 
-func (h *ReservationHandler) GetReservationByID(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
+// BestAvailable memilih seat terbaik untuk jumlah orang tertentu, misalnya
+// untuk kiosk yang tidak memilih seat satu per satu.
+func (h *ReservationHandler) BestAvailable(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var req request.BestAvailableRequest
+	if !utils.BindAndValidate(c, &req) {
+		return
+	}
+
+	res, err := h.service.SelectBestAvailable(c.Request.Context(), user.ID, &req)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reservation ID", err)
+		respondReservationError(c, "Failed to select seats", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Seats selected successfully", res)
+}
+
+func (h *ReservationHandler) GetReservationByID(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
 		return
 	}
 
-	res, err := h.service.GetReservationByID(c.Request.Context(), uint(id))
+	res, err := h.service.GetReservationByID(c.Request.Context(), user, id)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusNotFound, "Reservation not found", err)
+		respondReservationError(c, "Failed to fetch reservation", err)
 		return
 	}
 
 	utils.RespondWithSuccess(c, "Reservation fetched successfully", res)
 }
 
+func (h *ReservationHandler) GetHoldStatus(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.GetHoldStatus(c.Request.Context(), user, id)
+	if err != nil {
+		respondReservationError(c, "Failed to fetch reservation hold", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, "Reservation hold fetched successfully", res)
+}
+
+// GetReservationHistory mengembalikan audit trail reservation.
+func (h *ReservationHandler) GetReservationHistory(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.service.GetReservationHistory(c.Request.Context(), user, id)
+	if err != nil {
+		respondReservationError(c, "Failed to fetch reservation history", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, "Reservation history fetched successfully", events)
+}
+
 func (h *ReservationHandler) GetAllReservations(c *gin.Context) {
-	res, err := h.service.GetAllReservations(c.Request.Context())
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var filter request.ReservationFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	res, err := h.service.GetAllReservations(c.Request.Context(), user, filter)
 	if err != nil {
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch reservations", err)
 		return
@@ -70,18 +145,203 @@ func (h *ReservationHandler) GetAllReservations(c *gin.Context) {
 	utils.RespondWithSuccess(c, "Reservations fetched successfully", res)
 }
 
+func (h *ReservationHandler) GetUserReservations(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var filter request.ReservationFilterRequest
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	res, err := h.service.GetUserReservations(c.Request.Context(), user.ID, filter)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch reservation history", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation history fetched successfully", res)
+}
+
 func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteReservation(c.Request.Context(), user, id); err != nil {
+		respondReservationError(c, "Failed to delete reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation cancelled successfully", nil)
+}
+
+func (h *ReservationHandler) ConfirmReservation(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.ConfirmReservation(c.Request.Context(), user, id)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reservation ID", err)
+		respondReservationError(c, "Failed to confirm reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation confirmed successfully", res)
+}
+
+func (h *ReservationHandler) CancelReservation(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	// body opsional, hanya berisi alasan pembatalan
+	var req request.CancelReservationRequest
+	if c.Request.ContentLength > 0 && !utils.BindAndValidate(c, &req) {
 		return
 	}
 
-	err = h.service.DeleteReservation(c.Request.Context(), uint(id))
+	res, err := h.service.CancelReservation(c.Request.Context(), user, id, req.Reason)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete reservation", err)
+		respondReservationError(c, "Failed to cancel reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation cancelled successfully", res)
+}
+
+func (h *ReservationHandler) ModifyReservation(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpdateReservationRequest
+	if !utils.BindAndValidate(c, &req) {
+		return
+	}
+
+	res, err := h.service.ModifyReservation(c.Request.Context(), user, id, &req)
+	if err != nil {
+		respondReservationError(c, "Failed to modify reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation modified successfully", res)
+}
+
+// AcceptReschedule dipakai user untuk menerima jadwal showtime yang diubah.
+func (h *ReservationHandler) AcceptReschedule(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.AcceptReschedule(c.Request.Context(), user, id)
+	if err != nil {
+		respondReservationError(c, "Failed to accept new schedule", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "New schedule accepted", res)
+}
+
+// DeclineReschedule membatalkan reservation (dengan refund) karena jadwal showtime diubah.
+func (h *ReservationHandler) DeclineReschedule(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
 		return
 	}
-	utils.RespondWithSuccess(c, "Reservation deleted successfully", nil)
+
+	res, err := h.service.DeclineReschedule(c.Request.Context(), user, id)
+	if err != nil {
+		respondReservationError(c, "Failed to decline new schedule", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation cancelled due to schedule change", res)
+}
+
+func (h *ReservationHandler) CheckInReservation(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.CheckInReservation(c.Request.Context(), user, id)
+	if err != nil {
+		respondReservationError(c, "Failed to check in reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation checked in successfully", res)
+}
+
+func (h *ReservationHandler) RefundReservation(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.RefundReservation(c.Request.Context(), user, id)
+	if err != nil {
+		respondReservationError(c, "Failed to refund reservation", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Reservation refunded successfully", res)
+}
+
+// userAndReservationID mengambil user login dan parameter :id, dan langsung
+// menulis response error jika salah satunya tidak valid.
+func userAndReservationID(c *gin.Context) (*userModel.User, uint, bool) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return nil, 0, false
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reservation ID", err)
+		return nil, 0, false
+	}
+	return user, uint(id), true
+}
+
+// respondReservationError memetakan error service ke status HTTP yang sesuai.
+func respondReservationError(c *gin.Context, msg string, err error) {
+	var conflict *repository.SeatConflictError
+	var transition *model.TransitionError
+	var limit *service.LimitError
+	var gap *service.SeatGapError
+	switch {
+	case errors.As(err, &gap):
+		utils.RespondWithErrorDetails(c, http.StatusUnprocessableEntity, "Seat selection leaves a single empty seat", err, gap)
+	case errors.As(err, &limit):
+		utils.RespondWithErrorDetails(c, http.StatusUnprocessableEntity, "Booking limit exceeded", err, limit)
+	case errors.As(err, &conflict):
+		utils.RespondWithErrorDetails(c, http.StatusConflict, "Seat already taken", err, gin.H{"seat_ids": conflict.SeatIDs})
+	case errors.As(err, &transition):
+		utils.RespondWithErrorDetails(c, http.StatusConflict, msg, err, gin.H{
+			"status":          transition.From,
+			"allowed_actions": model.AllowedActions(transition.From),
+		})
+	case errors.Is(err, model.ErrNotRefundable):
+		utils.RespondWithError(c, http.StatusConflict, msg, err)
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		utils.RespondWithError(c, http.StatusUnprocessableEntity, msg, err)
+	case errors.Is(err, service.ErrIdempotencyKeyInFlight):
+		utils.RespondWithError(c, http.StatusConflict, msg, err)
+	case errors.Is(err, repository.ErrSeatNotInShowtime):
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid seat selection", err)
+	case errors.Is(err, service.ErrForbidden):
+		utils.RespondWithError(c, http.StatusForbidden, msg, err)
+	case errors.Is(err, service.ErrHoldExpired), errors.Is(err, service.ErrCancelCutoff), errors.Is(err, service.ErrNotModifiable):
+		utils.RespondWithError(c, http.StatusConflict, msg, err)
+	case errors.Is(err, service.ErrNotEnoughSeats), errors.Is(err, showtimeModel.ErrShowtimeCancelled), errors.Is(err, repository.ErrShowtimeStarted), errors.Is(err, service.ErrNoPendingReschedule):
+		utils.RespondWithError(c, http.StatusConflict, msg, err)
+	case errors.Is(err, service.ErrDifferentMovie), errors.Is(err, pricing.ErrInvalidTicketType):
+		utils.RespondWithError(c, http.StatusBadRequest, msg, err)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, "Reservation not found", err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, msg, err)
+	}
 }
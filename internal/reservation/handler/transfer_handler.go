@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TransferHandler struct {
+	service service.TransferService
+}
+
+func NewTransferHandler(service service.TransferService) *TransferHandler {
+	return &TransferHandler{service: service}
+}
+
+func (h *TransferHandler) RequestTransfer(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	var req request.TransferRequest
+	if ok := utils.BindAndValidate(c, &req); !ok {
+		return
+	}
+
+	res, err := h.service.RequestTransfer(c.Request.Context(), user, id, &req)
+	if err != nil {
+		respondTransferError(c, "Failed to request transfer", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Transfer requested successfully", res)
+}
+
+func (h *TransferHandler) GetMyTransfers(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.GetMyTransfers(c.Request.Context(), user)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch transfers", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Transfers fetched successfully", res)
+}
+
+func (h *TransferHandler) AcceptTransfer(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := transferID(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.AcceptTransfer(c.Request.Context(), user, id)
+	if err != nil {
+		respondTransferError(c, "Failed to accept transfer", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Transfer accepted successfully", res)
+}
+
+func (h *TransferHandler) DeclineTransfer(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := transferID(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.DeclineTransfer(c.Request.Context(), user, id)
+	if err != nil {
+		respondTransferError(c, "Failed to decline transfer", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Transfer declined successfully", res)
+}
+
+func (h *TransferHandler) CancelTransfer(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := transferID(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.CancelTransfer(c.Request.Context(), user, id)
+	if err != nil {
+		respondTransferError(c, "Failed to cancel transfer", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Transfer cancelled successfully", res)
+}
+
+func transferID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("transfer_id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid transfer ID", err)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// respondTransferError memetakan error transfer; error reservation lainnya
+// diteruskan ke respondReservationError.
+func respondTransferError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, service.ErrRecipientNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, msg, err)
+	case errors.Is(err, service.ErrTransferToSelf), errors.Is(err, service.ErrSeatNotInReservation):
+		utils.RespondWithError(c, http.StatusBadRequest, msg, err)
+	case errors.Is(err, service.ErrTransferForbidden):
+		utils.RespondWithError(c, http.StatusForbidden, msg, err)
+	case errors.Is(err, service.ErrTransferNotAllowed), errors.Is(err, service.ErrTransferPending), errors.Is(err, repository.ErrTransferNotPending):
+		utils.RespondWithError(c, http.StatusConflict, msg, err)
+	case errors.Is(err, gorm.ErrRecordNotFound) && c.Param("transfer_id") != "":
+		utils.RespondWithError(c, http.StatusNotFound, "Transfer not found", err)
+	default:
+		respondReservationError(c, msg, err)
+	}
+}
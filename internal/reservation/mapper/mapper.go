@@ -1,11 +1,44 @@
 package mapper
 
 import (
+	"encoding/json"
+	"slices"
+	"time"
+
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/response"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
 )
 
 func ToReservationResponse(r *model.Reservation) *response.ReservationResponse {
+	var cancellation *response.CancellationResponse
+	if r.CancelledAt != nil {
+		cancellation = &response.CancellationResponse{
+			CancelledAt: *r.CancelledAt,
+			Reason:      r.CancelReason,
+		}
+		if r.CancelledBy != nil {
+			cancellation.CancelledBy = *r.CancelledBy
+		}
+	}
+
+	var modification *response.ModificationResponse
+	if r.ModifiedAt != nil {
+		modification = &response.ModificationResponse{
+			ModifiedAt: *r.ModifiedAt,
+			Count:      r.ModificationCount,
+			Note:       r.ModificationNote,
+		}
+	}
+
+	var reschedule *response.RescheduleResponse
+	if r.RescheduleStatus != "" && r.RescheduledFrom != nil {
+		reschedule = &response.RescheduleResponse{
+			Status:            r.RescheduleStatus,
+			PreviousStartTime: *r.RescheduledFrom,
+		}
+	}
+
 	return &response.ReservationResponse{
 		ID: r.ID,
 		User: response.UserResponse{
@@ -17,7 +50,13 @@ func ToReservationResponse(r *model.Reservation) *response.ReservationResponse {
 			StartTime: r.Showtime.StartTime,
 			EndTime:   r.Showtime.EndTime,
 		},
-		Status: r.Status,
+		Price:          toPriceResponse(r),
+		Status:         r.Status,
+		AllowedActions: model.AllowedActions(r.Status),
+		ExpiredAt:      r.ExpiredAt,
+		Cancellation:   cancellation,
+		Modification:   modification,
+		Reschedule:     reschedule,
 		Seats: func() []response.SeatResponse {
 			seats := make([]response.SeatResponse, 0, len(r.Seats))
 			for _, seat := range r.Seats {
@@ -32,6 +71,25 @@ func ToReservationResponse(r *model.Reservation) *response.ReservationResponse {
 	}
 }
 
+func toPriceResponse(r *model.Reservation) response.PriceResponse {
+	seats := make([]response.SeatPriceResponse, 0, len(r.SeatPrices))
+	for _, p := range r.SeatPrices {
+		seat := response.SeatPriceResponse{
+			SeatID:     p.SeatID,
+			TicketType: p.TicketType,
+			Price:      p.Price,
+		}
+		if p.PriceBreakdown != "" {
+			seat.Breakdown = json.RawMessage(p.PriceBreakdown)
+		}
+		seats = append(seats, seat)
+	}
+	slices.SortFunc(seats, func(a, b response.SeatPriceResponse) int {
+		return int(a.SeatID) - int(b.SeatID)
+	})
+	return response.PriceResponse{Total: r.TotalPrice, Seats: seats}
+}
+
 func ToReservationResponseList(reservations []model.Reservation) []response.ReservationResponse {
 	responses := make([]response.ReservationResponse, 0, len(reservations))
 	for i := range reservations {
@@ -39,3 +97,105 @@ func ToReservationResponseList(reservations []model.Reservation) []response.Rese
 	}
 	return responses
 }
+
+func ToReservationEventResponseList(events []model.ReservationEvent) []response.ReservationEventResponse {
+	responses := make([]response.ReservationEventResponse, 0, len(events))
+	for _, e := range events {
+		item := response.ReservationEventResponse{
+			ID:         e.ID,
+			Type:       e.Type,
+			ActorID:    e.ActorID,
+			ActorRole:  e.ActorRole,
+			FromStatus: e.FromStatus,
+			ToStatus:   e.ToStatus,
+			Reason:     e.Reason,
+			CreatedAt:  e.CreatedAt,
+		}
+		if e.OldValue != "" {
+			item.OldValue = json.RawMessage(e.OldValue)
+		}
+		if e.NewValue != "" {
+			item.NewValue = json.RawMessage(e.NewValue)
+		}
+		responses = append(responses, item)
+	}
+	return responses
+}
+
+func ToTransferResponse(t *model.ReservationTransfer) *response.TransferResponse {
+	// seat yang dipindahkan; transfer penuh memakai semua seat reservation
+	moved := t.Seats()
+	seats := make([]response.SeatResponse, 0, len(t.Reservation.Seats))
+	for _, seat := range t.Reservation.Seats {
+		if len(moved) > 0 && !slices.Contains(moved, seat.ID) {
+			continue
+		}
+		seats = append(seats, response.SeatResponse{
+			ID:         seat.ID,
+			SeatNumber: seat.SeatNumber,
+			Row:        seat.Row,
+		})
+	}
+
+	return &response.TransferResponse{
+		ID:               t.ID,
+		ReservationID:    t.ReservationID,
+		From:             response.UserResponse{ID: t.FromUser.ID, Name: t.FromUser.Name},
+		To:               response.UserResponse{ID: t.ToUser.ID, Name: t.ToUser.Name},
+		MovieTitle:       t.Reservation.Showtime.Movie.Title,
+		StartTime:        t.Reservation.Showtime.StartTime,
+		Seats:            seats,
+		Status:           t.Status,
+		NewReservationID: t.NewReservationID,
+		CreatedAt:        t.CreatedAt,
+		RespondedAt:      t.RespondedAt,
+	}
+}
+
+func ToTransferResponseList(transfers []model.ReservationTransfer) []response.TransferResponse {
+	responses := make([]response.TransferResponse, 0, len(transfers))
+	for i := range transfers {
+		responses = append(responses, *ToTransferResponse(&transfers[i]))
+	}
+	return responses
+}
+
+func ToHoldResponse(r *model.Reservation, now time.Time) *response.HoldResponse {
+	remaining := int64(0)
+	if r.Status == model.StatusPending && r.ExpiredAt.After(now) {
+		remaining = int64(r.ExpiredAt.Sub(now).Seconds())
+	}
+	return &response.HoldResponse{
+		ReservationID:    r.ID,
+		Status:           r.Status,
+		ExpiredAt:        r.ExpiredAt,
+		RemainingSeconds: remaining,
+	}
+}
+
+func ToBookingLimitResponse(l *model.BookingLimit, override bool) *response.BookingLimitResponse {
+	return &response.BookingLimitResponse{
+		UserID:                 l.UserID,
+		MaxSeatsPerReservation: l.MaxSeatsPerReservation,
+		MaxSeatsPerShowtime:    l.MaxSeatsPerShowtime,
+		MaxActiveReservations:  l.MaxActiveReservations,
+		Override:               override,
+		Note:                   l.Note,
+	}
+}
+
+func ToBestAvailableResponse(showtimeID uint, seats []seatModel.Seat, together bool) *response.BestAvailableResponse {
+	res := &response.BestAvailableResponse{
+		ShowtimeID: showtimeID,
+		Seats:      make([]response.SeatResponse, 0, len(seats)),
+		Together:   together,
+	}
+	for _, seat := range seats {
+		res.Seats = append(res.Seats, response.SeatResponse{
+			ID:         seat.ID,
+			SeatNumber: seat.SeatNumber,
+			Row:        seat.Row,
+		})
+	}
+	return res
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// BookingLimit adalah override batas booking untuk satu user, misalnya akun
+// grup atau korporat. Nilai 0 berarti memakai batas default.
+type BookingLimit struct {
+	ID                     uint   `gorm:"primaryKey"`
+	UserID                 uint   `gorm:"not null;uniqueIndex"`
+	MaxSeatsPerReservation int    `gorm:"not null;default:0"`
+	MaxSeatsPerShowtime    int    `gorm:"not null;default:0"`
+	MaxActiveReservations  int    `gorm:"not null;default:0"`
+	Note                   string `gorm:"type:varchar(255)"`
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// GuestAccessToken adalah kredensial magic link untuk reservation guest
+// checkout. Token diturunkan dengan HMAC dari ID reservation sehingga request
+// yang diulang mendapat token yang sama; yang disimpan hanya hash SHA-256-nya.
+type GuestAccessToken struct {
+	ID            uint   `gorm:"primaryKey"`
+	ReservationID uint   `gorm:"not null;uniqueIndex"`
+	TokenHash     string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	CreatedAt     time.Time
+}
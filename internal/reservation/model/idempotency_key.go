@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// IdempotencyKey menyimpan hasil pembuatan reservation per user untuk header
+// Idempotency-Key, sehingga request yang di-retry mendapat response yang sama.
+type IdempotencyKey struct {
+	ID            uint   `gorm:"primaryKey"`
+	UserID        uint   `gorm:"not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	Key           string `gorm:"size:255;not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	RequestHash   string `gorm:"size:64;not null"`
+	ReservationID *uint
+	Response      []byte `gorm:"type:jsonb"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
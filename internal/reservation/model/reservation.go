@@ -16,12 +16,45 @@ type Reservation struct {
 	ShowtimeID uint
 	Showtime   showtimeModel.Showtime `gorm:"foreignKey:ShowtimeID"`
 	Status     string                 `gorm:"type:varchar(20);default:'pending'"`
-	ExpiredAt  time.Time
+	// ExpiredAt adalah batas waktu hold seat untuk reservation pending
+	ExpiredAt time.Time
+	// ConfirmedAt terisi saat reservation dikonfirmasi (dibayar); hanya
+	// reservation yang pernah confirmed yang bisa di-refund
+	ConfirmedAt *time.Time
+
+	CancelledAt  *time.Time
+	CancelledBy  *uint
+	CancelReason string `gorm:"type:varchar(255)"`
+
+	// perubahan seat/showtime terakhir yang dilakukan customer
+	ModifiedAt        *time.Time
+	ModificationCount int    `gorm:"not null;default:0"`
+	ModificationNote  string `gorm:"type:text"`
+
+	// jadwal showtime diubah admin setelah reservation dibuat; user memilih
+	// menerima jadwal baru atau membatalkan dengan refund
+	RescheduleStatus string `gorm:"type:varchar(20)"`
+	RescheduledFrom  *time.Time
+
+	// TotalPrice adalah jumlah harga seat saat dipesan (satuan mata uang terkecil)
+	TotalPrice int64 `gorm:"not null;default:0"`
 
 	Seats []seatModel.Seat `gorm:"many2many:reservation_seats;"`
+	// SeatPrices adalah baris reservation_seats beserta harga yang dikutip
+	SeatPrices []ReservationSeat `gorm:"foreignKey:ReservationID"`
 }
+
+// Refundable bernilai true jika reservation pernah dibayar.
+func (r *Reservation) Refundable() bool {
+	return r.ConfirmedAt != nil
+}
+
 type ReservationSeat struct {
 	ID            uint `gorm:"primaryKey"`
 	ReservationID uint
 	SeatID        uint
+	TicketType    string `gorm:"type:varchar(20);not null;default:'adult'"`
+	Price         int64  `gorm:"not null;default:0"`
+	// PriceBreakdown adalah rincian harga (JSON) saat seat dipesan
+	PriceBreakdown string `gorm:"type:text"`
 }
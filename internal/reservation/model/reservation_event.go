@@ -0,0 +1,55 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Jenis event di audit trail reservation selain perubahan status; perubahan
+// status memakai nama aksi state machine (confirm, cancel, ...).
+const (
+	EventCreate           = "create"
+	EventModify           = "modify"
+	EventReschedule       = "reschedule"
+	EventRescheduleAccept = "reschedule_accept"
+	EventTransfer         = "transfer"
+)
+
+// ReservationEvent adalah satu baris audit trail reservation. Ditulis di
+// transaksi yang sama dengan perubahan yang dicatatnya. ActorID kosong
+// berarti perubahan dilakukan sistem.
+type ReservationEvent struct {
+	ID            uint   `gorm:"primaryKey"`
+	ReservationID uint   `gorm:"not null;index"`
+	Type          string `gorm:"type:varchar(30);not null"`
+	ActorID       *uint
+	ActorRole     string `gorm:"type:varchar(20)"`
+	FromStatus    string `gorm:"type:varchar(20)"`
+	ToStatus      string `gorm:"type:varchar(20)"`
+	OldValue      string `gorm:"type:text"`
+	NewValue      string `gorm:"type:text"`
+	Reason        string `gorm:"type:varchar(255)"`
+	CreatedAt     time.Time
+}
+
+// SeatSnapshot adalah nilai lama/baru untuk event yang mengubah seat.
+type SeatSnapshot struct {
+	ShowtimeID uint   `json:"showtime_id"`
+	SeatIDs    []uint `json:"seat_ids"`
+}
+
+func (s SeatSnapshot) String() string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// ScheduleSnapshot adalah nilai lama/baru untuk event perubahan jadwal showtime.
+type ScheduleSnapshot struct {
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
+
+func (s ScheduleSnapshot) String() string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
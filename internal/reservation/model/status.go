@@ -0,0 +1,100 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusCheckedIn = "checked_in"
+	StatusExpired   = "expired"
+	StatusCancelled = "cancelled"
+	StatusRefunded  = "refunded"
+	// status akhir setelah showtime selesai: completed jika sudah check-in,
+	// no_show jika tidak pernah datang
+	StatusCompleted = "completed"
+	StatusNoShow    = "no_show"
+)
+
+const (
+	ActionConfirm  = "confirm"
+	ActionCheckIn  = "check_in"
+	ActionCancel   = "cancel"
+	ActionRefund   = "refund"
+	ActionExpire   = "expire"
+	ActionComplete = "complete"
+)
+
+// Status perubahan jadwal showtime pada reservation (RescheduleStatus).
+const (
+	ReschedulePending  = "pending"
+	RescheduleAccepted = "accepted"
+	RescheduleDeclined = "declined"
+)
+
+// transitions mendefinisikan state machine reservation: status asal -> aksi -> status tujuan.
+var transitions = map[string]map[string]string{
+	StatusPending: {
+		ActionConfirm: StatusConfirmed,
+		ActionCancel:  StatusCancelled,
+		ActionExpire:  StatusExpired,
+	},
+	StatusConfirmed: {
+		ActionCheckIn:  StatusCheckedIn,
+		ActionCancel:   StatusCancelled,
+		ActionComplete: StatusNoShow,
+	},
+	StatusCheckedIn: {
+		ActionComplete: StatusCompleted,
+	},
+	StatusCancelled: {
+		ActionRefund: StatusRefunded,
+	},
+}
+
+// systemActions hanya dijalankan oleh proses internal, tidak ditawarkan ke client.
+var systemActions = []string{ActionExpire, ActionComplete}
+
+var (
+	ErrInvalidTransition = errors.New("invalid reservation status transition")
+	// ErrNotRefundable dikembalikan saat refund diminta untuk reservation yang
+	// dibatalkan sebelum pernah dibayar (hold pending)
+	ErrNotRefundable = errors.New("reservation was never paid and cannot be refunded")
+)
+
+type TransitionError struct {
+	From   string
+	Action string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot %s reservation with status %q", e.Action, e.From)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// NextStatus mengembalikan status tujuan untuk aksi dari status saat ini.
+func NextStatus(from, action string) (string, error) {
+	to, ok := transitions[from][action]
+	if !ok {
+		return "", &TransitionError{From: from, Action: action}
+	}
+	return to, nil
+}
+
+// AllowedActions mengembalikan aksi yang bisa dilakukan client dari status saat ini.
+func AllowedActions(status string) []string {
+	actions := make([]string, 0, len(transitions[status]))
+	for action := range transitions[status] {
+		if !slices.Contains(systemActions, action) {
+			actions = append(actions, action)
+		}
+	}
+	slices.Sort(actions)
+	return actions
+}
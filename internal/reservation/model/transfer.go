@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+)
+
+const (
+	TransferPending   = "pending"
+	TransferAccepted  = "accepted"
+	TransferDeclined  = "declined"
+	TransferCancelled = "cancelled"
+)
+
+// ReservationTransfer adalah permintaan memindahkan reservation confirmed (atau
+// sebagian seat-nya) ke user lain. Reservation baru berpindah setelah penerima
+// menerima transfer.
+type ReservationTransfer struct {
+	ID            uint           `gorm:"primaryKey"`
+	ReservationID uint           `gorm:"not null;index"`
+	Reservation   Reservation    `gorm:"foreignKey:ReservationID"`
+	FromUserID    uint           `gorm:"not null;index"`
+	FromUser      userModel.User `gorm:"foreignKey:FromUserID"`
+	ToUserID      uint           `gorm:"not null;index"`
+	ToUser        userModel.User `gorm:"foreignKey:ToUserID"`
+	// SeatIDs berisi JSON daftar seat yang dipindahkan; kosong berarti seluruh reservation
+	SeatIDs string `gorm:"type:text"`
+	Status  string `gorm:"type:varchar(20);not null;default:'pending'"`
+	// NewReservationID terisi jika hanya sebagian seat yang dipindahkan
+	NewReservationID *uint
+	RespondedAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// Seats mengembalikan seat yang dipindahkan, nil berarti seluruh reservation.
+func (t *ReservationTransfer) Seats() []uint {
+	if t.SeatIDs == "" {
+		return nil
+	}
+	var ids []uint
+	if err := json.Unmarshal([]byte(t.SeatIDs), &ids); err != nil {
+		return nil
+	}
+	return ids
+}
+
+func (t *ReservationTransfer) SetSeats(ids []uint) {
+	if len(ids) == 0 {
+		t.SeatIDs = ""
+		return
+	}
+	b, _ := json.Marshal(ids)
+	t.SeatIDs = string(b)
+}
+
+// TransferSnapshot adalah nilai lama/baru event transfer di audit trail.
+type TransferSnapshot struct {
+	UserID        uint   `json:"user_id"`
+	ReservationID uint   `json:"reservation_id,omitempty"`
+	SeatIDs       []uint `json:"seat_ids"`
+}
+
+func (s TransferSnapshot) String() string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingLimitRepository interface {
+	GetByUserID(ctx context.Context, userID uint) (*model.BookingLimit, error)
+	GetAll(ctx context.Context) ([]model.BookingLimit, error)
+	Upsert(ctx context.Context, limit *model.BookingLimit) error
+	Delete(ctx context.Context, userID uint) error
+}
+
+type bookingLimitRepository struct {
+	db *gorm.DB
+}
+
+func NewBookingLimitRepository(db *gorm.DB) BookingLimitRepository {
+	return &bookingLimitRepository{db: db}
+}
+
+// GetByUserID mengembalikan nil tanpa error jika user tidak punya override.
+func (r *bookingLimitRepository) GetByUserID(ctx context.Context, userID uint) (*model.BookingLimit, error) {
+	var limit model.BookingLimit
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&limit).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		utils.ErrorLogger.Printf("Error to get booking limit (user: %d): %v", userID, err)
+		return nil, fmt.Errorf("failed to get booking limit: %w", err)
+	}
+	return &limit, nil
+}
+
+func (r *bookingLimitRepository) GetAll(ctx context.Context) ([]model.BookingLimit, error) {
+	var limits []model.BookingLimit
+	if err := r.db.WithContext(ctx).Order("user_id").Find(&limits).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to get booking limits: %v", err)
+		return nil, fmt.Errorf("failed to get booking limits: %w", err)
+	}
+	return limits, nil
+}
+
+func (r *bookingLimitRepository) Upsert(ctx context.Context, limit *model.BookingLimit) error {
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"max_seats_per_reservation", "max_seats_per_showtime", "max_active_reservations", "note", "updated_at",
+		}),
+	}).Create(limit).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to save booking limit (user: %d): %v", limit.UserID, err)
+		return fmt.Errorf("failed to save booking limit: %w", err)
+	}
+	return nil
+}
+
+func (r *bookingLimitRepository) Delete(ctx context.Context, userID uint) error {
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&model.BookingLimit{}).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to delete booking limit (user: %d): %v", userID, err)
+		return fmt.Errorf("failed to delete booking limit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GuestAccessRepository interface {
+	GetByTokenHash(ctx context.Context, tokenHash string) (*model.GuestAccessToken, error)
+	Create(ctx context.Context, token *model.GuestAccessToken) (bool, error)
+}
+
+type guestAccessRepository struct {
+	db *gorm.DB
+}
+
+func NewGuestAccessRepository(db *gorm.DB) GuestAccessRepository {
+	return &guestAccessRepository{db: db}
+}
+
+func (r *guestAccessRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*model.GuestAccessToken, error) {
+	var token model.GuestAccessToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, fmt.Errorf("failed to get guest access token: %w", err)
+	}
+	return &token, nil
+}
+
+// Create menyimpan token reservation dan mengembalikan false jika reservation
+// sudah punya token (misalnya saat request guest checkout diulang dengan
+// Idempotency-Key yang sama); token yang sudah ada tidak diubah.
+func (r *guestAccessRepository) Create(ctx context.Context, token *model.GuestAccessToken) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "reservation_id"}},
+		DoNothing: true,
+	}).Create(token)
+	if result.Error != nil {
+		utils.ErrorLogger.Printf("Error to save guest access token (reservation: %d): %v", token.ReservationID, result.Error)
+		return false, fmt.Errorf("failed to save guest access token: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
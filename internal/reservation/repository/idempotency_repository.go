@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepository interface {
+	Claim(ctx context.Context, key *model.IdempotencyKey, staleBefore time.Time) (bool, *model.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, id uint, reservationID uint, response []byte) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Claim mencoba menyimpan key baru. Jika key sudah dipakai user yang sama,
+// claimed bernilai false dan record yang sudah ada dikembalikan. Key yang
+// masih in-flight (belum ada reservation maupun response) dan terakhir diklaim
+// sebelum staleBefore dianggap ditinggalkan (proses crash sebelum reservation
+// dibuat) dan diambil alih oleh request ini.
+func (r *idempotencyRepository) Claim(ctx context.Context, key *model.IdempotencyKey, staleBefore time.Time) (bool, *model.IdempotencyKey, error) {
+	res := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if res.Error != nil {
+		utils.ErrorLogger.Printf("Error to claim idempotency key (user: %d): %v", key.UserID, res.Error)
+		return false, nil, fmt.Errorf("failed to claim idempotency key: %w", res.Error)
+	}
+	if res.RowsAffected > 0 {
+		return true, key, nil
+	}
+
+	var existing model.IdempotencyKey
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND key = ?", key.UserID, key.Key).
+		First(&existing).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to get idempotency key (user: %d): %v", key.UserID, err)
+		return false, nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	if len(existing.Response) > 0 || existing.ReservationID != nil || existing.RequestHash != key.RequestHash || !existing.UpdatedAt.Before(staleBefore) {
+		return false, &existing, nil
+	}
+
+	// kondisi diulang di UPDATE agar hanya satu request yang berhasil mengambil alih
+	res = r.db.WithContext(ctx).
+		Model(&model.IdempotencyKey{}).
+		Where("id = ? AND reservation_id IS NULL AND response IS NULL AND updated_at < ?", existing.ID, staleBefore).
+		Update("updated_at", time.Now())
+	if res.Error != nil {
+		utils.ErrorLogger.Printf("Error to take over idempotency key (ID: %d): %v", existing.ID, res.Error)
+		return false, nil, fmt.Errorf("failed to take over idempotency key: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return false, &existing, nil
+	}
+	utils.InfoLogger.Printf("Took over stale idempotency key (ID: %d)", existing.ID)
+	return true, &existing, nil
+}
+
+func (r *idempotencyRepository) SaveResponse(ctx context.Context, id uint, reservationID uint, response []byte) error {
+	err := r.db.WithContext(ctx).
+		Model(&model.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"reservation_id": reservationID, "response": response}).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to save idempotent response (ID: %d): %v", id, err)
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Delete melepas key yang belum terhubung ke reservation. Key yang sudah
+// terhubung tetap disimpan agar retry memutar ulang reservation tersebut.
+func (r *idempotencyRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.db.WithContext(ctx).Where("reservation_id IS NULL").Delete(&model.IdempotencyKey{}, id).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to delete idempotency key (ID: %d): %v", id, err)
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
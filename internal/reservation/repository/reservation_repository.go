@@ -2,18 +2,34 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	pricingModel "github.com/didanslmn/movie-reservation-system.git/internal/pricing/model"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+	showtimeRepository "github.com/didanslmn/movie-reservation-system.git/internal/showtime/repository"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
 	"github.com/didanslmn/movie-reservation-system.git/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ReservationRepository interface {
-	Create(ctx context.Context, reservation *model.Reservation) error
+	Create(ctx context.Context, reservation *model.Reservation, seatIDs []uint, event *model.ReservationEvent, guard *BookingGuard, idempotencyKeyID uint) error
 	GetByID(ctx context.Context, id uint) (*model.Reservation, error)
-	GetAll(ctx context.Context) ([]model.Reservation, error)
+	GetAll(ctx context.Context, filter ReservationFilter) ([]model.Reservation, error)
 	Delete(ctx context.Context, id uint) error
+	ExpirePending(ctx context.Context, now time.Time, limit int) ([]model.Reservation, error)
+	CompleteFinished(ctx context.Context, now time.Time, limit int) ([]model.Reservation, error)
+	Transition(ctx context.Context, id uint, action string, event *model.ReservationEvent, apply func(r *model.Reservation) error) (*model.Reservation, error)
+	CancelAndRefund(ctx context.Context, id uint, event *model.ReservationEvent, apply func(r *model.Reservation) error) (*model.Reservation, error)
+	Modify(ctx context.Context, id uint, showtimeID uint, seatIDs []uint, event *model.ReservationEvent, guard *BookingGuard, apply func(r *model.Reservation) error) (*model.Reservation, error)
+	GetEvents(ctx context.Context, reservationID uint) ([]model.ReservationEvent, error)
+	RescheduleShowtime(ctx context.Context, showtime *showtimeModel.Showtime, previousStart time.Time, event *model.ReservationEvent) ([]model.Reservation, error)
+	AcceptReschedule(ctx context.Context, id uint, event *model.ReservationEvent, apply func(r *model.Reservation) error) (*model.Reservation, error)
 }
 
 type reservationRepository struct {
@@ -24,12 +40,92 @@ func NewReservationRepository(db *gorm.DB) ReservationRepository {
 	return &reservationRepository{db: db}
 }
 
-func (r *reservationRepository) Create(ctx context.Context, reservation *model.Reservation) error {
-	if err := r.db.WithContext(ctx).Create(reservation).Error; err != nil {
-		utils.ErrorLogger.Printf("Error to create reservation: %v", err)
-		return fmt.Errorf("failed to create reservation: %w", err)
-	}
-	return nil
+var (
+	ErrSeatNotAvailable  = errors.New("seat already taken")
+	ErrSeatNotInShowtime = errors.New("seat is not part of this showtime")
+	ErrShowtimeStarted   = errors.New("showtime already started")
+)
+
+// SeatConflictError dikembalikan saat satu atau lebih seat sudah diambil
+// transaksi lain, berisi ID seat yang kalah.
+type SeatConflictError struct {
+	SeatIDs []uint
+}
+
+func (e *SeatConflictError) Error() string {
+	return fmt.Sprintf("seat already taken: %v", e.SeatIDs)
+}
+
+func (e *SeatConflictError) Unwrap() error {
+	return ErrSeatNotAvailable
+}
+
+// BookingGuard berisi validasi yang dijalankan Create dan Modify di dalam
+// transaksi booking, setelah baris yang relevan dikunci. Field nil dilewati.
+type BookingGuard struct {
+	// Limits menerima jumlah reservation aktif user dan kapasitas seat user di
+	// showtime tujuan, tanpa reservation yang sedang diubah. Baris user dikunci
+	// lebih dulu sehingga booking paralel milik user yang sama berjalan berurutan.
+	Limits func(active, showtimeSeats int64) error
+	// SeatGaps menerima seat showtime tujuan yang bisa dipesan, termasuk seat
+	// milik reservation yang sedang diubah. Semua seat showtime dikunci lebih
+	// dulu sehingga booking seat yang bersebelahan tidak bisa lolos bersamaan.
+	SeatGaps func(free map[uint]bool) error
+}
+
+// Create membuat reservation dan mengunci seat showtime dalam satu transaksi.
+// Baris showtime_seats dikunci dengan SELECT ... FOR UPDATE sehingga dua
+// pembeli tidak bisa mendapatkan seat yang sama. event berisi actor dan alasan;
+// sisanya diisi di sini.
+// Create menahan seat untuk reservation baru. idempotencyKeyID selain 0
+// dihubungkan ke reservation di transaksi yang sama, sehingga retry setelah
+// crash memutar ulang reservation ini alih-alih memesan lagi.
+func (r *reservationRepository) Create(ctx context.Context, reservation *model.Reservation, seatIDs []uint, event *model.ReservationEvent, guard *BookingGuard, idempotencyKeyID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockBookableShowtime(tx, reservation.ShowtimeID); err != nil {
+			return err
+		}
+		if err := guard.check(tx, reservation.UserID, reservation.ShowtimeID, 0); err != nil {
+			return err
+		}
+		seats, err := lockAvailableSeats(tx, reservation.ShowtimeID, seatIDs, 0)
+		if err != nil {
+			return err
+		}
+
+		prices := reservation.SeatPrices
+		if err := tx.Omit(clause.Associations).Create(reservation).Error; err != nil {
+			utils.ErrorLogger.Printf("Error to create reservation: %v", err)
+			return fmt.Errorf("failed to create reservation: %w", err)
+		}
+		reservation.Seats = seats
+		if reservation.SeatPrices, err = saveSeatLinks(tx, reservation.ID, seatIDs, prices); err != nil {
+			return err
+		}
+
+		err = tx.Model(&seatModel.ShowtimeSeat{}).
+			Where("showtime_id = ? AND seat_id IN ?", reservation.ShowtimeID, seatIDs).
+			Updates(map[string]any{"status": seatModel.ShowtimeSeatHeld, "reservation_id": reservation.ID}).Error
+		if err != nil {
+			utils.ErrorLogger.Printf("Error to hold seats %v: %v", seatIDs, err)
+			return fmt.Errorf("failed to hold seats: %w", err)
+		}
+
+		if idempotencyKeyID != 0 {
+			err = tx.Model(&model.IdempotencyKey{}).
+				Where("id = ?", idempotencyKeyID).
+				Update("reservation_id", reservation.ID).Error
+			if err != nil {
+				utils.ErrorLogger.Printf("Error to link idempotency key (ID: %d): %v", idempotencyKeyID, err)
+				return fmt.Errorf("failed to link idempotency key: %w", err)
+			}
+		}
+
+		event.Type = model.EventCreate
+		event.ToStatus = reservation.Status
+		event.NewValue = model.SeatSnapshot{ShowtimeID: reservation.ShowtimeID, SeatIDs: seatIDs}.String()
+		return saveEvent(tx, reservation.ID, event)
+	})
 }
 
 func (r *reservationRepository) GetByID(ctx context.Context, id uint) (*model.Reservation, error) {
@@ -37,7 +133,10 @@ func (r *reservationRepository) GetByID(ctx context.Context, id uint) (*model.Re
 	err := r.db.WithContext(ctx).
 		Preload("User").
 		Preload("Showtime").
-		Preload("Seat").
+		Preload("Showtime.Movie").
+		Preload("Showtime.CinemaHall").
+		Preload("Seats").
+		Preload("SeatPrices").
 		First(&reservation, id).Error
 	if err != nil {
 		utils.ErrorLogger.Printf("Error to get reservation by ID %d: %v", id, err)
@@ -46,12 +145,44 @@ func (r *reservationRepository) GetByID(ctx context.Context, id uint) (*model.Re
 	return &reservation, nil
 }
 
-func (r *reservationRepository) GetAll(ctx context.Context) ([]model.Reservation, error) {
+// ReservationFilter membatasi hasil GetAll. Field kosong tidak dipakai.
+// From dan To dibandingkan dengan jadwal mulai showtime.
+type ReservationFilter struct {
+	UserID     uint
+	ShowtimeID uint
+	Status     string
+	From       *time.Time
+	To         *time.Time
+}
+
+func (r *reservationRepository) GetAll(ctx context.Context, filter ReservationFilter) ([]model.Reservation, error) {
 	var reservations []model.Reservation
-	err := r.db.WithContext(ctx).
+	query := r.db.WithContext(ctx).
+		Joins("JOIN showtimes ON showtimes.id = reservations.showtime_id")
+	if filter.UserID != 0 {
+		query = query.Where("reservations.user_id = ?", filter.UserID)
+	}
+	if filter.ShowtimeID != 0 {
+		query = query.Where("reservations.showtime_id = ?", filter.ShowtimeID)
+	}
+	if filter.Status != "" {
+		query = query.Where("reservations.status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("showtimes.start_time >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("showtimes.start_time < ?", *filter.To)
+	}
+
+	err := query.
 		Preload("User").
 		Preload("Showtime").
-		Preload("Seat").
+		Preload("Showtime.Movie").
+		Preload("Showtime.CinemaHall").
+		Preload("Seats").
+		Preload("SeatPrices").
+		Order("showtimes.start_time DESC").
 		Find(&reservations).Error
 	if err != nil {
 		utils.ErrorLogger.Printf("Error to get all reservations: %v", err)
@@ -67,3 +198,621 @@ func (r *reservationRepository) Delete(ctx context.Context, id uint) error {
 	}
 	return nil
 }
+
+// ExpirePending mengubah reservation pending yang melewati batas hold menjadi
+// expired dan melepas seat-nya. Baris yang sedang dikunci transaksi lain
+// dilewati dan akan diproses pada putaran berikutnya.
+func (r *reservationRepository) ExpirePending(ctx context.Context, now time.Time, limit int) ([]model.Reservation, error) {
+	var expired []model.Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Preload("Seats").
+			Where("status = ? AND expired_at <= ?", model.StatusPending, now).
+			Order("expired_at").
+			Limit(limit).
+			Find(&expired).Error
+		if err != nil {
+			return fmt.Errorf("failed to find expired reservations: %w", err)
+		}
+		if len(expired) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(expired))
+		events := make([]model.ReservationEvent, 0, len(expired))
+		for i := range expired {
+			ids = append(ids, expired[i].ID)
+			events = append(events, model.ReservationEvent{
+				ReservationID: expired[i].ID,
+				Type:          model.ActionExpire,
+				FromStatus:    expired[i].Status,
+				ToStatus:      model.StatusExpired,
+				Reason:        "hold expired",
+			})
+			expired[i].Status = model.StatusExpired
+		}
+		if err := tx.Model(&model.Reservation{}).Where("id IN ?", ids).Update("status", model.StatusExpired).Error; err != nil {
+			return fmt.Errorf("failed to expire reservations: %w", err)
+		}
+		if err := tx.Create(&events).Error; err != nil {
+			return fmt.Errorf("failed to save reservation events: %w", err)
+		}
+		return releaseSeats(tx, ids)
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to expire pending reservations: %v", err)
+		return nil, err
+	}
+	return expired, nil
+}
+
+// CompleteFinished menutup reservation confirmed/checked_in yang showtime-nya
+// sudah selesai: checked_in menjadi completed, confirmed menjadi no_show.
+// Seat tetap booked karena showtime sudah lewat.
+func (r *reservationRepository) CompleteFinished(ctx context.Context, now time.Time, limit int) ([]model.Reservation, error) {
+	var finished []model.Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{
+			Strength: "UPDATE",
+			Table:    clause.Table{Name: "reservations"},
+			Options:  "SKIP LOCKED",
+		}).
+			Select("reservations.*").
+			Joins("JOIN showtimes ON showtimes.id = reservations.showtime_id").
+			Where("reservations.status IN ? AND showtimes.end_time <= ?", []string{model.StatusConfirmed, model.StatusCheckedIn}, now).
+			Order("showtimes.end_time").
+			Limit(limit).
+			Find(&finished).Error
+		if err != nil {
+			return fmt.Errorf("failed to find finished reservations: %w", err)
+		}
+		if len(finished) == 0 {
+			return nil
+		}
+
+		byStatus := make(map[string][]uint)
+		events := make([]model.ReservationEvent, 0, len(finished))
+		for i := range finished {
+			next, err := model.NextStatus(finished[i].Status, model.ActionComplete)
+			if err != nil {
+				return err
+			}
+			byStatus[next] = append(byStatus[next], finished[i].ID)
+			events = append(events, model.ReservationEvent{
+				ReservationID: finished[i].ID,
+				Type:          model.ActionComplete,
+				FromStatus:    finished[i].Status,
+				ToStatus:      next,
+				Reason:        "showtime finished",
+			})
+			finished[i].Status = next
+		}
+		for status, ids := range byStatus {
+			if err := tx.Model(&model.Reservation{}).Where("id IN ?", ids).Update("status", status).Error; err != nil {
+				return fmt.Errorf("failed to complete reservations: %w", err)
+			}
+		}
+		if err := tx.Create(&events).Error; err != nil {
+			return fmt.Errorf("failed to save reservation events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to complete finished reservations: %v", err)
+		return nil, err
+	}
+	return finished, nil
+}
+
+// Transition menjalankan aksi state machine pada reservation yang dikunci.
+// apply (opsional) dipanggil sebelum status diubah untuk validasi tambahan
+// seperti kepemilikan atau batas waktu; error dari apply membatalkan transaksi.
+// Perubahan status dicatat sebagai event dengan actor dari event.
+func (r *reservationRepository) Transition(ctx context.Context, id uint, action string, event *model.ReservationEvent, apply func(r *model.Reservation) error) (*model.Reservation, error) {
+	var reservation model.Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Showtime").
+			First(&reservation, id).Error
+		if err != nil {
+			return fmt.Errorf("failed to get reservation by id: %w", err)
+		}
+		return transition(tx, &reservation, action, event, apply)
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to %s reservation (ID: %d): %v", action, id, err)
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// CancelAndRefund membatalkan reservation dan, jika reservation pernah dibayar,
+// langsung me-refund-nya dalam transaksi yang sama, sehingga tidak ada
+// reservation yang tertinggal cancelled tanpa refund jika proses terhenti.
+// apply dipanggil untuk transisi cancel seperti pada Transition.
+func (r *reservationRepository) CancelAndRefund(ctx context.Context, id uint, event *model.ReservationEvent, apply func(r *model.Reservation) error) (*model.Reservation, error) {
+	var reservation model.Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Showtime").
+			First(&reservation, id).Error
+		if err != nil {
+			return fmt.Errorf("failed to get reservation by id: %w", err)
+		}
+
+		refund := *event
+		if err := transition(tx, &reservation, model.ActionCancel, event, apply); err != nil {
+			return err
+		}
+		if !reservation.Refundable() {
+			return nil
+		}
+		return transition(tx, &reservation, model.ActionRefund, &refund, nil)
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to cancel and refund reservation (ID: %d): %v", id, err)
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// TransitionLocked mengunci reservation id di dalam transaksi tx, memanggil
+// check dengan data yang sudah dikunci, lalu menjalankan aksi action. Dipakai
+// repository lain yang harus mengubah datanya sendiri bersama status
+// reservation (misalnya check-in tiket).
+func TransitionLocked(tx *gorm.DB, id uint, action string, event *model.ReservationEvent, check func(r *model.Reservation) error) (*model.Reservation, error) {
+	var reservation model.Reservation
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Showtime").
+		First(&reservation, id).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation by id: %w", err)
+	}
+	if err := check(&reservation); err != nil {
+		return nil, err
+	}
+	if err := transition(tx, &reservation, action, event, nil); err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// transition menjalankan satu aksi state machine pada reservation yang sudah
+// dikunci di tx, lalu menyimpan status, event, dan status seat-nya.
+func transition(tx *gorm.DB, reservation *model.Reservation, action string, event *model.ReservationEvent, apply func(r *model.Reservation) error) error {
+	next, err := model.NextStatus(reservation.Status, action)
+	if err != nil {
+		return err
+	}
+	if action == model.ActionRefund && !reservation.Refundable() {
+		return model.ErrNotRefundable
+	}
+	if apply != nil {
+		if err := apply(reservation); err != nil {
+			return err
+		}
+	}
+	event.Type = action
+	event.FromStatus = reservation.Status
+	event.ToStatus = next
+	reservation.Status = next
+	if next == model.StatusConfirmed {
+		now := time.Now()
+		reservation.ConfirmedAt = &now
+	}
+
+	if err := tx.Omit(clause.Associations).Save(reservation).Error; err != nil {
+		return fmt.Errorf("failed to update reservation: %w", err)
+	}
+	if err := saveEvent(tx, reservation.ID, event); err != nil {
+		return err
+	}
+
+	switch next {
+	case model.StatusConfirmed:
+		err = tx.Model(&seatModel.ShowtimeSeat{}).
+			Where("reservation_id = ?", reservation.ID).
+			Update("status", seatModel.ShowtimeSeatBooked).Error
+	case model.StatusCancelled, model.StatusExpired:
+		err = releaseSeats(tx, []uint{reservation.ID})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update seats: %w", err)
+	}
+	return nil
+}
+
+// Modify memindahkan reservation ke seat (dan showtime) baru dalam satu
+// transaksi; showtimeID 0 berarti tetap di showtime sekarang. Seat baru dikunci dan ditahan lebih dulu, baru seat lama dilepas,
+// sehingga reservation tidak pernah kehilangan seat jika seat baru gagal didapat.
+func (r *reservationRepository) Modify(ctx context.Context, id uint, showtimeID uint, seatIDs []uint, event *model.ReservationEvent, guard *BookingGuard, apply func(r *model.Reservation) error) (*model.Reservation, error) {
+	var reservation model.Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Showtime").
+			Preload("Seats").
+			First(&reservation, id).Error
+		if err != nil {
+			return fmt.Errorf("failed to get reservation by id: %w", err)
+		}
+		if apply != nil {
+			if err := apply(&reservation); err != nil {
+				return err
+			}
+		}
+		if showtimeID == 0 {
+			showtimeID = reservation.ShowtimeID
+		}
+		if err := lockBookableShowtime(tx, showtimeID); err != nil {
+			return err
+		}
+		if err := guard.check(tx, reservation.UserID, showtimeID, reservation.ID); err != nil {
+			return err
+		}
+
+		seats, err := lockAvailableSeats(tx, showtimeID, seatIDs, reservation.ID)
+		if err != nil {
+			return err
+		}
+
+		seatStatus := seatModel.ShowtimeSeatHeld
+		if reservation.Status == model.StatusConfirmed {
+			seatStatus = seatModel.ShowtimeSeatBooked
+		}
+		err = tx.Model(&seatModel.ShowtimeSeat{}).
+			Where("showtime_id = ? AND seat_id IN ?", showtimeID, seatIDs).
+			Updates(map[string]any{"status": seatStatus, "reservation_id": reservation.ID}).Error
+		if err != nil {
+			return fmt.Errorf("failed to hold new seats: %w", err)
+		}
+
+		// lepas seat lama yang tidak lagi dipakai
+		err = tx.Model(&seatModel.ShowtimeSeat{}).
+			Where("reservation_id = ? AND NOT (showtime_id = ? AND seat_id IN ?)", reservation.ID, showtimeID, seatIDs).
+			Updates(map[string]any{"status": seatModel.ShowtimeSeatAvailable, "reservation_id": nil}).Error
+		if err != nil {
+			return fmt.Errorf("failed to release old seats: %w", err)
+		}
+
+		if err := tx.Where("reservation_id = ?", reservation.ID).Delete(&model.ReservationSeat{}).Error; err != nil {
+			return fmt.Errorf("failed to clear reservation seats: %w", err)
+		}
+		// harga seat baru diisi apply lewat reservation.SeatPrices
+		if reservation.SeatPrices, err = saveSeatLinks(tx, reservation.ID, seatIDs, reservation.SeatPrices); err != nil {
+			return err
+		}
+
+		old := model.SeatSnapshot{ShowtimeID: reservation.ShowtimeID}
+		for _, seat := range reservation.Seats {
+			old.SeatIDs = append(old.SeatIDs, seat.ID)
+		}
+		event.Type = model.EventModify
+		event.FromStatus = reservation.Status
+		event.ToStatus = reservation.Status
+		event.OldValue = old.String()
+		event.NewValue = model.SeatSnapshot{ShowtimeID: showtimeID, SeatIDs: seatIDs}.String()
+
+		reservation.ShowtimeID = showtimeID
+		reservation.Seats = seats
+		if err := tx.Omit(clause.Associations).Save(&reservation).Error; err != nil {
+			return fmt.Errorf("failed to update reservation: %w", err)
+		}
+		return saveEvent(tx, reservation.ID, event)
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to modify reservation (ID: %d): %v", id, err)
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// saveSeatLinks menyimpan baris reservation_seats untuk seatIDs beserta harga
+// dari prices (dicocokkan lewat SeatID). Seat tanpa harga disimpan dengan harga nol.
+func saveSeatLinks(tx *gorm.DB, reservationID uint, seatIDs []uint, prices []model.ReservationSeat) ([]model.ReservationSeat, error) {
+	links := make([]model.ReservationSeat, 0, len(seatIDs))
+	for _, seatID := range seatIDs {
+		link := model.ReservationSeat{SeatID: seatID, TicketType: pricingModel.TicketAdult}
+		for _, p := range prices {
+			if p.SeatID == seatID {
+				link = p
+				break
+			}
+		}
+		link.ID = 0
+		link.ReservationID = reservationID
+		links = append(links, link)
+	}
+	if err := tx.Create(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to save reservation seats: %w", err)
+	}
+	return links, nil
+}
+
+// refreshTotalPrice menghitung ulang total harga dari seat yang dipegang reservation.
+func refreshTotalPrice(tx *gorm.DB, reservationIDs ...uint) error {
+	err := tx.Model(&model.Reservation{}).
+		Where("id IN ?", reservationIDs).
+		Update("total_price", gorm.Expr("(SELECT COALESCE(SUM(price), 0) FROM reservation_seats WHERE reservation_seats.reservation_id = reservations.id)")).Error
+	if err != nil {
+		return fmt.Errorf("failed to update total price: %w", err)
+	}
+	return nil
+}
+
+// lockBookableShowtime mengunci showtime dengan FOR KEY SHARE lalu memastikan
+// showtime belum dibatalkan dan belum mulai. Lock ini bentrok dengan FOR UPDATE
+// dari pembatalan dan perubahan showtime, tapi tidak dengan lock waitlist.
+func lockBookableShowtime(tx *gorm.DB, showtimeID uint) error {
+	var showtime showtimeModel.Showtime
+	err := tx.Clauses(clause.Locking{Strength: "KEY SHARE"}).First(&showtime, showtimeID).Error
+	if err != nil {
+		return fmt.Errorf("failed to lock showtime: %w", err)
+	}
+	if showtime.Status == showtimeModel.StatusCancelled {
+		return showtimeModel.ErrShowtimeCancelled
+	}
+	if !showtime.StartTime.After(time.Now()) {
+		return ErrShowtimeStarted
+	}
+	return nil
+}
+
+// lockAvailableSeats mengunci baris showtime_seats untuk seatIDs dan memastikan
+// semuanya bisa dipakai. Seat yang sudah dipegang reservationID dianggap tersedia.
+func lockAvailableSeats(tx *gorm.DB, showtimeID uint, seatIDs []uint, reservationID uint) ([]seatModel.Seat, error) {
+	var showtimeSeats []seatModel.ShowtimeSeat
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("showtime_id = ? AND seat_id IN ?", showtimeID, seatIDs).
+		Order("seat_id").
+		Find(&showtimeSeats).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to lock seats %v: %v", seatIDs, err)
+		return nil, fmt.Errorf("failed to lock seats: %w", err)
+	}
+	if len(showtimeSeats) != len(seatIDs) {
+		return nil, ErrSeatNotInShowtime
+	}
+
+	var seats []seatModel.Seat
+	if err := tx.Where("id IN ?", seatIDs).Find(&seats).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to get seats %v: %v", seatIDs, err)
+		return nil, fmt.Errorf("failed to get seats: %w", err)
+	}
+	blocked := make(map[uint]bool, len(seats))
+	for _, seat := range seats {
+		blocked[seat.ID] = seat.Status != "available"
+	}
+
+	var taken []uint
+	for _, ss := range showtimeSeats {
+		ownedBySelf := reservationID != 0 && ss.ReservationID != nil && *ss.ReservationID == reservationID
+		if blocked[ss.SeatID] || (ss.Status != seatModel.ShowtimeSeatAvailable && !ownedBySelf) {
+			taken = append(taken, ss.SeatID)
+		}
+	}
+	if len(taken) > 0 {
+		return nil, &SeatConflictError{SeatIDs: taken}
+	}
+	return seats, nil
+}
+
+// check menjalankan guard di dalam transaksi booking. reservationID adalah
+// reservation yang sedang diubah (0 untuk reservation baru).
+func (g *BookingGuard) check(tx *gorm.DB, userID uint, showtimeID uint, reservationID uint) error {
+	if g == nil {
+		return nil
+	}
+
+	if g.Limits != nil {
+		err := tx.Clauses(clause.Locking{Strength: "NO KEY UPDATE"}).
+			Select("id").
+			Take(&userModel.User{}, userID).Error
+		if err != nil {
+			return fmt.Errorf("failed to lock user: %w", err)
+		}
+		active, err := countActiveByUser(tx, userID, reservationID, time.Now())
+		if err != nil {
+			return err
+		}
+		held, err := countSeatsByUserAndShowtime(tx, userID, showtimeID, reservationID)
+		if err != nil {
+			return err
+		}
+		if err := g.Limits(active, held); err != nil {
+			return err
+		}
+	}
+
+	if g.SeatGaps != nil {
+		free, err := lockShowtimeSeats(tx, showtimeID, reservationID)
+		if err != nil {
+			return err
+		}
+		if err := g.SeatGaps(free); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockShowtimeSeats mengunci semua seat showtime (urut seat_id, sama dengan
+// lockAvailableSeats, agar tidak deadlock) dan mengembalikan seat yang bisa
+// dipesan. Seat milik reservationID ikut dianggap bisa dipesan.
+func lockShowtimeSeats(tx *gorm.DB, showtimeID uint, reservationID uint) (map[uint]bool, error) {
+	var showtimeSeats []seatModel.ShowtimeSeat
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Seat").
+		Where("showtime_id = ?", showtimeID).
+		Order("seat_id").
+		Find(&showtimeSeats).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to lock seats of showtime %d: %v", showtimeID, err)
+		return nil, fmt.Errorf("failed to lock showtime seats: %w", err)
+	}
+
+	free := make(map[uint]bool, len(showtimeSeats))
+	for _, ss := range showtimeSeats {
+		ownedBySelf := reservationID != 0 && ss.ReservationID != nil && *ss.ReservationID == reservationID
+		if ss.Seat.Status == "available" && (ss.Status == seatModel.ShowtimeSeatAvailable || ownedBySelf) {
+			free[ss.SeatID] = true
+		}
+	}
+	return free, nil
+}
+
+// countActiveByUser menghitung reservation user yang masih berjalan: pending
+// yang hold-nya masih berlaku, serta confirmed dan checked_in yang showtime-nya
+// belum selesai.
+func countActiveByUser(tx *gorm.DB, userID uint, excludeReservationID uint, now time.Time) (int64, error) {
+	var count int64
+	err := tx.Model(&model.Reservation{}).
+		Joins("JOIN showtimes ON showtimes.id = reservations.showtime_id").
+		Where("reservations.user_id = ? AND reservations.id <> ?", userID, excludeReservationID).
+		Where("(reservations.status = ? AND reservations.expired_at > ?) OR (reservations.status IN ? AND showtimes.end_time > ?)",
+			model.StatusPending, now, []string{model.StatusConfirmed, model.StatusCheckedIn}, now).
+		Count(&count).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to count active reservations (user: %d): %v", userID, err)
+		return 0, fmt.Errorf("failed to count active reservations: %w", err)
+	}
+	return count, nil
+}
+
+// countSeatsByUserAndShowtime menghitung kapasitas seat (jumlah orang) yang
+// sedang dipegang user di satu showtime.
+func countSeatsByUserAndShowtime(tx *gorm.DB, userID uint, showtimeID uint, excludeReservationID uint) (int64, error) {
+	var count int64
+	err := tx.Model(&seatModel.ShowtimeSeat{}).
+		Select("COALESCE(SUM(COALESCE(seat_categories.capacity, 1)), 0)").
+		Joins("JOIN reservations ON reservations.id = showtime_seats.reservation_id").
+		Joins("JOIN seats ON seats.id = showtime_seats.seat_id").
+		Joins("LEFT JOIN seat_categories ON seat_categories.name = seats.category").
+		Where("reservations.user_id = ? AND showtime_seats.showtime_id = ? AND reservations.id <> ?", userID, showtimeID, excludeReservationID).
+		Scan(&count).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to count seats (user: %d, showtime: %d): %v", userID, showtimeID, err)
+		return 0, fmt.Errorf("failed to count seats: %w", err)
+	}
+	return count, nil
+}
+
+// GetEvents mengembalikan audit trail reservation dari yang paling lama.
+func (r *reservationRepository) GetEvents(ctx context.Context, reservationID uint) ([]model.ReservationEvent, error) {
+	var events []model.ReservationEvent
+	err := r.db.WithContext(ctx).
+		Where("reservation_id = ?", reservationID).
+		Order("created_at, id").
+		Find(&events).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to get events of reservation %d: %v", reservationID, err)
+		return nil, fmt.Errorf("failed to get reservation events: %w", err)
+	}
+	return events, nil
+}
+
+// RescheduleShowtime menyimpan jadwal baru showtime dan menandai reservation
+// pending dan confirmed-nya dalam satu transaksi, agar user memilih menerima
+// atau membatalkan. Jika salah satu gagal, jadwal showtime juga tidak berubah.
+// previousStart adalah jadwal mulai sebelum perubahan; nilai lama/baru event
+// diisi pemanggil.
+func (r *reservationRepository) RescheduleShowtime(ctx context.Context, showtime *showtimeModel.Showtime, previousStart time.Time, event *model.ReservationEvent) ([]model.Reservation, error) {
+	showtimeID := showtime.ID
+	var reservations []model.Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := showtimeRepository.SaveShowtime(tx, showtime); err != nil {
+			return err
+		}
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("showtime_id = ? AND status IN ?", showtimeID, []string{model.StatusPending, model.StatusConfirmed}).
+			Find(&reservations).Error
+		if err != nil {
+			return fmt.Errorf("failed to find reservations: %w", err)
+		}
+		if len(reservations) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(reservations))
+		events := make([]model.ReservationEvent, 0, len(reservations))
+		for i := range reservations {
+			ids = append(ids, reservations[i].ID)
+			e := *event
+			e.ReservationID = reservations[i].ID
+			e.Type = model.EventReschedule
+			e.FromStatus = reservations[i].Status
+			e.ToStatus = reservations[i].Status
+			events = append(events, e)
+		}
+		// jadwal asal pertama tetap disimpan jika showtime diubah beberapa kali
+		err = tx.Model(&model.Reservation{}).Where("id IN ?", ids).
+			Updates(map[string]any{
+				"reschedule_status": model.ReschedulePending,
+				"rescheduled_from":  gorm.Expr("COALESCE(rescheduled_from, ?)", previousStart),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to mark reservations rescheduled: %w", err)
+		}
+		if err := tx.Create(&events).Error; err != nil {
+			return fmt.Errorf("failed to save reservation events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to reschedule showtime %d: %v", showtimeID, err)
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// AcceptReschedule mencatat bahwa user menerima jadwal showtime yang baru.
+// apply dipakai untuk validasi kepemilikan dan status reschedule.
+func (r *reservationRepository) AcceptReschedule(ctx context.Context, id uint, event *model.ReservationEvent, apply func(r *model.Reservation) error) (*model.Reservation, error) {
+	var reservation model.Reservation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Showtime").
+			First(&reservation, id).Error
+		if err != nil {
+			return fmt.Errorf("failed to get reservation by id: %w", err)
+		}
+		if err := apply(&reservation); err != nil {
+			return err
+		}
+
+		reservation.RescheduleStatus = model.RescheduleAccepted
+		if err := tx.Omit(clause.Associations).Save(&reservation).Error; err != nil {
+			return fmt.Errorf("failed to update reservation: %w", err)
+		}
+		event.Type = model.EventRescheduleAccept
+		event.FromStatus = reservation.Status
+		event.ToStatus = reservation.Status
+		return saveEvent(tx, reservation.ID, event)
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to accept reschedule of reservation (ID: %d): %v", id, err)
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// saveEvent menulis event audit trail di dalam transaksi perubahan.
+func saveEvent(tx *gorm.DB, reservationID uint, event *model.ReservationEvent) error {
+	event.ID = 0
+	event.ReservationID = reservationID
+	if err := tx.Create(event).Error; err != nil {
+		return fmt.Errorf("failed to save reservation event: %w", err)
+	}
+	return nil
+}
+
+// releaseSeats mengembalikan seat milik reservation ke status available.
+func releaseSeats(tx *gorm.DB, reservationIDs []uint) error {
+	err := tx.Model(&seatModel.ShowtimeSeat{}).
+		Where("reservation_id IN ?", reservationIDs).
+		Updates(map[string]any{"status": seatModel.ShowtimeSeatAvailable, "reservation_id": nil}).Error
+	if err != nil {
+		return fmt.Errorf("failed to release seats: %w", err)
+	}
+	return nil
+}
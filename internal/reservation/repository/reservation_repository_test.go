@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// testDB membuka database dari TEST_DATABASE_URL dengan schema sementara yang
+// dihapus setelah test selesai. Test dilewati jika variabel tidak diisi.
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	config := &gorm.Config{
+		Logger:                                   logger.Default.LogMode(logger.Silent),
+		DisableForeignKeyConstraintWhenMigrating: true,
+	}
+	admin, err := gorm.Open(postgres.Open(dsn), config)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	schema := fmt.Sprintf("reservation_test_%d", time.Now().UnixNano())
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	// search_path dipasang di DSN agar berlaku di semua koneksi pool
+	if strings.Contains(dsn, "://") {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		dsn += sep + "search_path=" + schema
+	} else {
+		dsn += " search_path=" + schema
+	}
+	db, err := gorm.Open(postgres.Open(dsn), config)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	// reservation_seats dibuat lebih dulu agar join table many2many memakai model ini
+	if err := db.AutoMigrate(&model.ReservationSeat{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	err = db.AutoMigrate(&showtimeModel.Showtime{}, &seatModel.Seat{}, &seatModel.ShowtimeSeat{}, &model.Reservation{}, &model.ReservationEvent{})
+	if err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+func TestCreateConcurrentBookingOfOneSeat(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	const showtimeID = 1
+
+	showtime := showtimeModel.Showtime{MovieID: 1, CinemaHallID: 1, StartTime: time.Now().Add(time.Hour), EndTime: time.Now().Add(3 * time.Hour), Status: showtimeModel.StatusScheduled}
+	showtime.ID = showtimeID
+	if err := db.Omit(clause.Associations).Create(&showtime).Error; err != nil {
+		t.Fatalf("failed to create showtime: %v", err)
+	}
+	seat := seatModel.Seat{CinemaHallID: 1, Row: "A", SeatNumber: "1", Status: "available", Category: seatModel.CategoryStandard}
+	if err := db.Omit(clause.Associations).Create(&seat).Error; err != nil {
+		t.Fatalf("failed to create seat: %v", err)
+	}
+	err := db.Omit(clause.Associations).Create(&seatModel.ShowtimeSeat{ShowtimeID: showtimeID, SeatID: seat.ID, Status: seatModel.ShowtimeSeatAvailable}).Error
+	if err != nil {
+		t.Fatalf("failed to create showtime seat: %v", err)
+	}
+
+	repo := NewReservationRepository(db)
+	const buyers = 20
+	errs := make([]error, buyers)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range buyers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			reservation := &model.Reservation{
+				UserID:     uint(i + 1),
+				ShowtimeID: showtimeID,
+				Status:     model.StatusPending,
+				ExpiredAt:  time.Now().Add(10 * time.Minute),
+			}
+			errs[i] = repo.Create(ctx, reservation, []uint{seat.ID}, &model.ReservationEvent{}, nil, 0)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	var succeeded, conflicts int
+	for _, err := range errs {
+		var conflict *SeatConflictError
+		switch {
+		case err == nil:
+			succeeded++
+		case errors.As(err, &conflict):
+			conflicts++
+			if len(conflict.SeatIDs) != 1 || conflict.SeatIDs[0] != seat.ID {
+				t.Errorf("conflict seat IDs = %v, want [%d]", conflict.SeatIDs, seat.ID)
+			}
+			if !errors.Is(err, ErrSeatNotAvailable) {
+				t.Errorf("conflict does not unwrap to ErrSeatNotAvailable: %v", err)
+			}
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if succeeded != 1 || conflicts != buyers-1 {
+		t.Fatalf("succeeded = %d, conflicts = %d, want 1 and %d", succeeded, conflicts, buyers-1)
+	}
+
+	var reservations []model.Reservation
+	if err := db.Find(&reservations).Error; err != nil {
+		t.Fatalf("failed to get reservations: %v", err)
+	}
+	if len(reservations) != 1 {
+		t.Fatalf("reservations = %d, want 1", len(reservations))
+	}
+	var showtimeSeat seatModel.ShowtimeSeat
+	if err := db.First(&showtimeSeat, "seat_id = ?", seat.ID).Error; err != nil {
+		t.Fatalf("failed to get showtime seat: %v", err)
+	}
+	if showtimeSeat.Status != seatModel.ShowtimeSeatHeld || showtimeSeat.ReservationID == nil || *showtimeSeat.ReservationID != reservations[0].ID {
+		t.Errorf("showtime seat = %+v, want held by reservation %d", showtimeSeat, reservations[0].ID)
+	}
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	ticketModel "github.com/didanslmn/movie-reservation-system.git/internal/ticket/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TransferRepository interface {
+	Create(ctx context.Context, transfer *model.ReservationTransfer) error
+	GetByID(ctx context.Context, id uint) (*model.ReservationTransfer, error)
+	GetByUser(ctx context.Context, userID uint) ([]model.ReservationTransfer, error)
+	HasPending(ctx context.Context, reservationID uint) (bool, error)
+	UpdateStatus(ctx context.Context, id uint, status string, at time.Time) (bool, error)
+	Accept(ctx context.Context, id uint, event *model.ReservationEvent, apply func(t *model.ReservationTransfer, r *model.Reservation) error, guardFor func(seats []seatModel.Seat) (*BookingGuard, error)) (*model.ReservationTransfer, error)
+}
+
+type transferRepository struct {
+	db *gorm.DB
+}
+
+func NewTransferRepository(db *gorm.DB) TransferRepository {
+	return &transferRepository{db: db}
+}
+
+var ErrTransferNotPending = errors.New("transfer is no longer pending")
+
+func (r *transferRepository) Create(ctx context.Context, transfer *model.ReservationTransfer) error {
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Create(transfer).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to create transfer (reservation: %d): %v", transfer.ReservationID, err)
+		return fmt.Errorf("failed to create transfer: %w", err)
+	}
+	return nil
+}
+
+func (r *transferRepository) GetByID(ctx context.Context, id uint) (*model.ReservationTransfer, error) {
+	var transfer model.ReservationTransfer
+	err := r.db.WithContext(ctx).
+		Preload("FromUser").
+		Preload("ToUser").
+		Preload("Reservation.Showtime.Movie").
+		Preload("Reservation.Seats").
+		First(&transfer, id).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to get transfer by ID %d: %v", id, err)
+		return nil, fmt.Errorf("failed to get transfer by id: %w", err)
+	}
+	return &transfer, nil
+}
+
+// GetByUser mengembalikan transfer yang dikirim maupun diterima user, terbaru lebih dulu.
+func (r *transferRepository) GetByUser(ctx context.Context, userID uint) ([]model.ReservationTransfer, error) {
+	var transfers []model.ReservationTransfer
+	err := r.db.WithContext(ctx).
+		Preload("FromUser").
+		Preload("ToUser").
+		Preload("Reservation.Showtime.Movie").
+		Preload("Reservation.Seats").
+		Where("from_user_id = ? OR to_user_id = ?", userID, userID).
+		Order("created_at DESC").
+		Find(&transfers).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to get transfers (user: %d): %v", userID, err)
+		return nil, fmt.Errorf("failed to get transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+func (r *transferRepository) HasPending(ctx context.Context, reservationID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&model.ReservationTransfer{}).
+		Where("reservation_id = ? AND status = ?", reservationID, model.TransferPending).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check pending transfer: %w", err)
+	}
+	return count > 0, nil
+}
+
+// UpdateStatus menutup transfer pending (declined atau cancelled). Hasil false
+// berarti transfer sudah tidak pending.
+func (r *transferRepository) UpdateStatus(ctx context.Context, id uint, status string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&model.ReservationTransfer{}).
+		Where("id = ? AND status = ?", id, model.TransferPending).
+		Updates(map[string]any{"status": status, "responded_at": at})
+	if result.Error != nil {
+		utils.ErrorLogger.Printf("Error to update transfer %d to %s: %v", id, status, result.Error)
+		return false, fmt.Errorf("failed to update transfer: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Accept memindahkan reservation ke penerima dalam satu transaksi. Jika hanya
+// sebagian seat yang dipindahkan, seat tersebut dipecah ke reservation baru
+// milik penerima. Tiket lama yang belum dipakai dicabut agar QR milik pengirim
+// tidak bisa dipakai lagi. apply dipanggil untuk validasi setelah baris dikunci,
+// lalu guard dari guardFor (untuk seat yang dipindahkan) dijalankan atas nama
+// penerima agar transfer tidak melewati batas booking-nya.
+func (r *transferRepository) Accept(ctx context.Context, id uint, event *model.ReservationEvent, apply func(t *model.ReservationTransfer, r *model.Reservation) error, guardFor func(seats []seatModel.Seat) (*BookingGuard, error)) (*model.ReservationTransfer, error) {
+	var transfer model.ReservationTransfer
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transfer, id).Error; err != nil {
+			return fmt.Errorf("failed to get transfer by id: %w", err)
+		}
+		if transfer.Status != model.TransferPending {
+			return ErrTransferNotPending
+		}
+
+		var reservation model.Reservation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Showtime").
+			Preload("Seats.CategoryInfo").
+			First(&reservation, transfer.ReservationID).Error
+		if err != nil {
+			return fmt.Errorf("failed to get reservation by id: %w", err)
+		}
+		if err := apply(&transfer, &reservation); err != nil {
+			return err
+		}
+
+		allSeats := make([]uint, 0, len(reservation.Seats))
+		for _, seat := range reservation.Seats {
+			allSeats = append(allSeats, seat.ID)
+		}
+		moved := transfer.Seats()
+		movedSeats := reservation.Seats
+		if len(moved) > 0 {
+			movedSeats = make([]seatModel.Seat, 0, len(moved))
+			for _, seat := range reservation.Seats {
+				if slices.Contains(moved, seat.ID) {
+					movedSeats = append(movedSeats, seat)
+				}
+			}
+		}
+		guard, err := guardFor(movedSeats)
+		if err != nil {
+			return err
+		}
+		if err := guard.check(tx, transfer.ToUserID, reservation.ShowtimeID, 0); err != nil {
+			return err
+		}
+
+		if len(moved) == 0 || len(moved) == len(allSeats) {
+			moved = allSeats
+			if err := moveWholeReservation(tx, &reservation, transfer.ToUserID, event); err != nil {
+				return err
+			}
+		} else {
+			newID, err := splitReservation(tx, &reservation, transfer.ToUserID, moved, event)
+			if err != nil {
+				return err
+			}
+			transfer.NewReservationID = &newID
+		}
+
+		if err := tx.Where("reservation_id = ? AND used_at IS NULL", reservation.ID).Delete(&ticketModel.Ticket{}).Error; err != nil {
+			return fmt.Errorf("failed to revoke ticket: %w", err)
+		}
+
+		now := time.Now()
+		transfer.Status = model.TransferAccepted
+		transfer.RespondedAt = &now
+		if err := tx.Omit(clause.Associations).Save(&transfer).Error; err != nil {
+			return fmt.Errorf("failed to update transfer: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to accept transfer (ID: %d): %v", id, err)
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// moveWholeReservation mengganti pemilik reservation dan mencatatnya di audit trail.
+func moveWholeReservation(tx *gorm.DB, reservation *model.Reservation, toUserID uint, event *model.ReservationEvent) error {
+	seatIDs := make([]uint, 0, len(reservation.Seats))
+	for _, seat := range reservation.Seats {
+		seatIDs = append(seatIDs, seat.ID)
+	}
+
+	fromUserID := reservation.UserID
+	if err := tx.Model(&model.Reservation{}).Where("id = ?", reservation.ID).Update("user_id", toUserID).Error; err != nil {
+		return fmt.Errorf("failed to transfer reservation: %w", err)
+	}
+	reservation.UserID = toUserID
+
+	event.Type = model.EventTransfer
+	event.FromStatus = reservation.Status
+	event.ToStatus = reservation.Status
+	event.OldValue = model.TransferSnapshot{UserID: fromUserID, SeatIDs: seatIDs}.String()
+	event.NewValue = model.TransferSnapshot{UserID: toUserID, SeatIDs: seatIDs}.String()
+	return saveEvent(tx, reservation.ID, event)
+}
+
+// splitReservation memindahkan seatIDs ke reservation confirmed baru milik
+// penerima dan mencatat transfer di audit trail kedua reservation.
+func splitReservation(tx *gorm.DB, reservation *model.Reservation, toUserID uint, seatIDs []uint, event *model.ReservationEvent) (uint, error) {
+	split := &model.Reservation{
+		UserID:     toUserID,
+		ShowtimeID: reservation.ShowtimeID,
+		Status:     reservation.Status,
+		ExpiredAt:  reservation.ExpiredAt,
+
+		ConfirmedAt:      reservation.ConfirmedAt,
+		RescheduleStatus: reservation.RescheduleStatus,
+		RescheduledFrom:  reservation.RescheduledFrom,
+	}
+	if err := tx.Omit(clause.Associations).Create(split).Error; err != nil {
+		return 0, fmt.Errorf("failed to create reservation for recipient: %w", err)
+	}
+
+	err := tx.Model(&model.ReservationSeat{}).
+		Where("reservation_id = ? AND seat_id IN ?", reservation.ID, seatIDs).
+		Update("reservation_id", split.ID).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to move reservation seats: %w", err)
+	}
+	err = tx.Model(&seatModel.ShowtimeSeat{}).
+		Where("reservation_id = ? AND seat_id IN ?", reservation.ID, seatIDs).
+		Update("reservation_id", split.ID).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to move showtime seats: %w", err)
+	}
+	if err := refreshTotalPrice(tx, reservation.ID, split.ID); err != nil {
+		return 0, err
+	}
+
+	allSeats := make([]uint, 0, len(reservation.Seats))
+	for _, seat := range reservation.Seats {
+		allSeats = append(allSeats, seat.ID)
+	}
+
+	original := *event
+	original.Type = model.EventTransfer
+	original.FromStatus = reservation.Status
+	original.ToStatus = reservation.Status
+	original.OldValue = model.TransferSnapshot{UserID: reservation.UserID, SeatIDs: allSeats}.String()
+	original.NewValue = model.TransferSnapshot{UserID: toUserID, ReservationID: split.ID, SeatIDs: seatIDs}.String()
+	if err := saveEvent(tx, reservation.ID, &original); err != nil {
+		return 0, err
+	}
+
+	created := *event
+	created.Type = model.EventTransfer
+	created.ToStatus = split.Status
+	created.OldValue = model.TransferSnapshot{UserID: reservation.UserID, ReservationID: reservation.ID, SeatIDs: seatIDs}.String()
+	created.NewValue = model.TransferSnapshot{UserID: toUserID, SeatIDs: seatIDs}.String()
+	if err := saveEvent(tx, split.ID, &created); err != nil {
+		return 0, err
+	}
+	return split.ID, nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/handler"
 	"github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,15 +17,75 @@ func ReservationRoutes(rg *gin.RouterGroup, h *handler.ReservationHandler, jwtSe
 	publicRoutes.Use(middleware.RoleBasedAccess(model.RoleUser, model.RoleAdmin))
 	{
 		publicRoutes.POST("/", h.CreateReservation)
+		publicRoutes.POST("/best-available", h.BestAvailable)
 		publicRoutes.GET("/", h.GetAllReservations)
 		publicRoutes.GET("/:id", h.GetReservationByID)
+		publicRoutes.PATCH("/:id", h.ModifyReservation)
+		publicRoutes.GET("/:id/hold", h.GetHoldStatus)
+		publicRoutes.GET("/:id/history", h.GetReservationHistory)
+		publicRoutes.POST("/:id/confirm", h.ConfirmReservation)
+		publicRoutes.POST("/:id/cancel", h.CancelReservation)
+		publicRoutes.POST("/:id/reschedule/accept", h.AcceptReschedule)
+		publicRoutes.POST("/:id/reschedule/decline", h.DeclineReschedule)
 	}
 
-	// Hanya Admin yang dapat menghapus reservation
+	// Hanya Admin yang dapat check-in, refund, dan menghapus reservation
 	adminRoutes := reservations.Group("/")
 	adminRoutes.Use(middleware.JWTAuthMiddleware(jwtSecret))
 	adminRoutes.Use(middleware.RoleBasedAccess(model.RoleAdmin))
 	{
+		adminRoutes.POST("/:id/check-in", h.CheckInReservation)
+		adminRoutes.POST("/:id/refund", h.RefundReservation)
 		adminRoutes.DELETE("/:id", h.DeleteReservation)
 	}
 }
+
+// GuestRoutes didaftarkan tanpa JWT untuk pembeli tanpa akun; reservation
+// guest diakses lewat token magic link.
+func GuestRoutes(rg *gin.RouterGroup, h *handler.GuestHandler, checkoutLimiter *utils.RateLimiter) {
+	guest := rg.Group("/guest/reservations")
+	{
+		guest.POST("", middleware.RateLimitByIP(checkoutLimiter), h.Checkout)
+		guest.GET("/:token", h.GetReservation)
+		guest.POST("/:token/confirm", h.ConfirmReservation)
+		guest.POST("/:token/cancel", h.CancelReservation)
+	}
+}
+
+// TransferRoutes untuk memindahkan reservation ke user lain. Penerima harus
+// menerima transfer sebelum reservation berpindah pemilik.
+func TransferRoutes(rg *gin.RouterGroup, h *handler.TransferHandler, jwtSecret string) {
+	reservations := rg.Group("/reservations")
+	reservations.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	reservations.Use(middleware.RoleBasedAccess(model.RoleUser, model.RoleAdmin))
+	{
+		reservations.POST("/:id/transfer", h.RequestTransfer)
+		reservations.GET("/transfers", h.GetMyTransfers)
+		reservations.POST("/transfers/:transfer_id/accept", h.AcceptTransfer)
+		reservations.POST("/transfers/:transfer_id/decline", h.DeclineTransfer)
+		reservations.POST("/transfers/:transfer_id/cancel", h.CancelTransfer)
+	}
+}
+
+// UserReservationRoutes mendaftarkan riwayat reservation milik user login di bawah /user.
+func UserReservationRoutes(rg *gin.RouterGroup, h *handler.ReservationHandler, jwtSecret string) {
+	user := rg.Group("/user")
+	user.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	user.Use(middleware.RoleBasedAccess(model.RoleUser, model.RoleAdmin))
+	{
+		user.GET("/reservations", h.GetUserReservations)
+	}
+}
+
+// BookingLimitRoutes untuk admin mengatur batas booking per user (akun grup/korporat).
+func BookingLimitRoutes(rg *gin.RouterGroup, h *handler.BookingLimitHandler, jwtSecret string) {
+	limits := rg.Group("/reservations/limits")
+	limits.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	limits.Use(middleware.RoleBasedAccess(model.RoleAdmin))
+	{
+		limits.GET("/", h.GetAllOverrides)
+		limits.GET("/:user_id", h.GetUserLimit)
+		limits.PUT("/:user_id", h.SetUserLimit)
+		limits.DELETE("/:user_id", h.DeleteUserLimit)
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/didanslmn/movie-reservation-system.git/config"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	users "github.com/didanslmn/movie-reservation-system.git/internal/users/repository"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+)
+
+const (
+	LimitSeatsPerReservation = "max_seats_per_reservation"
+	LimitSeatsPerShowtime    = "max_seats_per_showtime"
+	LimitActiveReservations  = "max_active_reservations"
+)
+
+// LimitError dikembalikan saat booking melewati batas yang berlaku untuk user.
+type LimitError struct {
+	Limit     string `json:"limit"`
+	Max       int    `json:"max"`
+	Current   int    `json:"current"`
+	Requested int    `json:"requested"`
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("booking limit %s exceeded: max %d, current %d, requested %d", e.Limit, e.Max, e.Current, e.Requested)
+}
+
+type BookingLimitService interface {
+	GetEffectiveLimit(ctx context.Context, userID uint) (*response.BookingLimitResponse, error)
+	GetAllOverrides(ctx context.Context) ([]response.BookingLimitResponse, error)
+	SetOverride(ctx context.Context, userID uint, req request.BookingLimitRequest) (*response.BookingLimitResponse, error)
+	DeleteOverride(ctx context.Context, userID uint) error
+}
+
+type bookingLimitService struct {
+	limitRepo repository.BookingLimitRepository
+	userRepo  users.UserRepository
+	cfg       config.ReservationConfig
+}
+
+func NewBookingLimitService(limitRepo repository.BookingLimitRepository, userRepo users.UserRepository, cfg config.ReservationConfig) BookingLimitService {
+	return &bookingLimitService{
+		limitRepo: limitRepo,
+		userRepo:  userRepo,
+		cfg:       cfg,
+	}
+}
+
+// GetEffectiveLimit menggabungkan batas default dengan override admin untuk user.
+func (s *bookingLimitService) GetEffectiveLimit(ctx context.Context, userID uint) (*response.BookingLimitResponse, error) {
+	override, err := s.limitRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := model.BookingLimit{
+		UserID:                 userID,
+		MaxSeatsPerReservation: s.cfg.MaxSeatsPerReservation,
+		MaxSeatsPerShowtime:    s.cfg.MaxSeatsPerShowtime,
+		MaxActiveReservations:  s.cfg.MaxActiveReservations,
+	}
+	if override != nil {
+		if override.MaxSeatsPerReservation > 0 {
+			limit.MaxSeatsPerReservation = override.MaxSeatsPerReservation
+		}
+		if override.MaxSeatsPerShowtime > 0 {
+			limit.MaxSeatsPerShowtime = override.MaxSeatsPerShowtime
+		}
+		if override.MaxActiveReservations > 0 {
+			limit.MaxActiveReservations = override.MaxActiveReservations
+		}
+		limit.Note = override.Note
+	}
+	return mapper.ToBookingLimitResponse(&limit, override != nil), nil
+}
+
+func (s *bookingLimitService) GetAllOverrides(ctx context.Context) ([]response.BookingLimitResponse, error) {
+	limits, err := s.limitRepo.GetAll(ctx)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to get booking limit overrides: %v", err)
+		return nil, err
+	}
+	result := make([]response.BookingLimitResponse, 0, len(limits))
+	for i := range limits {
+		result = append(result, *mapper.ToBookingLimitResponse(&limits[i], true))
+	}
+	return result, nil
+}
+
+func (s *bookingLimitService) SetOverride(ctx context.Context, userID uint, req request.BookingLimitRequest) (*response.BookingLimitResponse, error) {
+	if exist, err := s.userRepo.ExistsByID(ctx, userID); err != nil || !exist {
+		utils.ErrorLogger.Printf("User not found (ID: %d): %v", userID, err)
+		return nil, fmt.Errorf("user not found")
+	}
+
+	limit := &model.BookingLimit{
+		UserID:                 userID,
+		MaxSeatsPerReservation: req.MaxSeatsPerReservation,
+		MaxSeatsPerShowtime:    req.MaxSeatsPerShowtime,
+		MaxActiveReservations:  req.MaxActiveReservations,
+		Note:                   req.Note,
+	}
+	if err := s.limitRepo.Upsert(ctx, limit); err != nil {
+		utils.ErrorLogger.Printf("Failed to save booking limit (user: %d): %v", userID, err)
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Booking limit override saved (user: %d)", userID)
+	return s.GetEffectiveLimit(ctx, userID)
+}
+
+func (s *bookingLimitService) DeleteOverride(ctx context.Context, userID uint) error {
+	if err := s.limitRepo.Delete(ctx, userID); err != nil {
+		utils.ErrorLogger.Printf("Failed to delete booking limit (user: %d): %v", userID, err)
+		return err
+	}
+	utils.InfoLogger.Printf("Booking limit override deleted (user: %d)", userID)
+	return nil
+}
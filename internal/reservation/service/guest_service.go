@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/config"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	users "github.com/didanslmn/movie-reservation-system.git/internal/users/repository"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidAccessToken   = errors.New("invalid guest access token")
+	ErrGuestEmailRegistered = errors.New("email belongs to a registered account, please log in")
+	ErrGuestCheckoutLimited = fmt.Errorf("too many guest checkouts for this email: %w", utils.ErrRateLimited)
+)
+
+// GuestService melayani pembeli tanpa akun. Guest disimpan sebagai user
+// dengan role guest sehingga limit, notifikasi, dan audit trail tetap berlaku;
+// reservation diakses lewat magic link, bukan JWT.
+type GuestService interface {
+	// Checkout membuat reservation guest. Magic link hanya dikirim ke email
+	// guest dan tidak pernah ada di response, sehingga token sekaligus menjadi
+	// bukti kepemilikan email (dipakai saat guest mendaftar).
+	Checkout(ctx context.Context, req *request.GuestReservationRequest, idempotencyKey string) (*response.ReservationResponse, error)
+	GetReservation(ctx context.Context, token string) (*response.ReservationResponse, error)
+	ConfirmReservation(ctx context.Context, token string) (*response.ReservationResponse, error)
+	CancelReservation(ctx context.Context, token, reason string) (*response.ReservationResponse, error)
+	// OwnsAccessToken memeriksa apakah token magic link milik reservation guestID
+	OwnsAccessToken(ctx context.Context, guestID uint, token string) (bool, error)
+	// ResolveAccessToken mengembalikan reservation lengkap milik token magic link
+	ResolveAccessToken(ctx context.Context, token string) (*model.Reservation, error)
+	OnAccessIssued(listener GuestAccessListener)
+}
+
+// GuestAccessListener dipanggil (di goroutine terpisah) setelah magic link
+// dibuat, misalnya untuk mengirim link ke email guest.
+type GuestAccessListener func(ctx context.Context, r *model.Reservation, accessURL string)
+
+type guestService struct {
+	guestRepo       repository.GuestAccessRepository
+	reservationRepo repository.ReservationRepository
+	userRepo        users.UserRepository
+	reservationSvc  ReservationService
+	// baseURL adalah alamat publik API untuk menyusun magic link
+	baseURL      string
+	tokenSecret  []byte
+	emailLimiter *utils.RateLimiter
+
+	accessListeners []GuestAccessListener
+}
+
+func NewGuestService(guestRepo repository.GuestAccessRepository, reservationRepo repository.ReservationRepository, userRepo users.UserRepository, reservationSvc ReservationService, baseURL string, cfg config.GuestConfig) GuestService {
+	return &guestService{
+		guestRepo:       guestRepo,
+		reservationRepo: reservationRepo,
+		userRepo:        userRepo,
+		reservationSvc:  reservationSvc,
+		baseURL:         baseURL,
+		tokenSecret:     []byte(cfg.TokenSecret),
+		emailLimiter:    utils.NewRateLimiter(cfg.CheckoutLimitPerEmail, cfg.CheckoutLimitWindow),
+	}
+}
+
+func (s *guestService) Checkout(ctx context.Context, req *request.GuestReservationRequest, idempotencyKey string) (*response.ReservationResponse, error) {
+	email := strings.TrimSpace(req.Email)
+	if !s.emailLimiter.Allow(strings.ToLower(email)) {
+		return nil, ErrGuestCheckoutLimited
+	}
+	guest, err := s.findOrCreateGuest(ctx, strings.TrimSpace(req.Name), email)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.reservationSvc.CreateReservation(ctx, guest.ID, &request.CreateReservationRequest{
+		ShowtimeID:  req.ShowtimeID,
+		SeatIDs:     req.SeatIDs,
+		TicketTypes: req.TicketTypes,
+	}, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// request yang diulang mendapat token yang sama dan link tidak dikirim ulang
+	token, issued, err := s.issueToken(ctx, reservation.ID)
+	if err != nil {
+		return nil, err
+	}
+	accessURL := fmt.Sprintf("%s/api/v1/guest/reservations/%s", strings.TrimRight(s.baseURL, "/"), token)
+	utils.InfoLogger.Printf("Guest reservation %d created (user: %d)", reservation.ID, guest.ID)
+
+	if issued && len(s.accessListeners) > 0 {
+		if r, err := s.reservationRepo.GetByID(ctx, reservation.ID); err == nil {
+			for _, listener := range s.accessListeners {
+				go listener(context.Background(), r, accessURL)
+			}
+		}
+	}
+
+	return reservation, nil
+}
+
+func (s *guestService) GetReservation(ctx context.Context, token string) (*response.ReservationResponse, error) {
+	reservation, err := s.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToReservationResponse(reservation), nil
+}
+
+func (s *guestService) ConfirmReservation(ctx context.Context, token string) (*response.ReservationResponse, error) {
+	reservation, err := s.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.reservationSvc.ConfirmReservation(ctx, &reservation.User, reservation.ID)
+}
+
+func (s *guestService) CancelReservation(ctx context.Context, token, reason string) (*response.ReservationResponse, error) {
+	reservation, err := s.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.reservationSvc.CancelReservation(ctx, &reservation.User, reservation.ID, reason)
+}
+
+func (s *guestService) OwnsAccessToken(ctx context.Context, guestID uint, token string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+	reservation, err := s.resolve(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAccessToken) {
+			return false, nil
+		}
+		return false, err
+	}
+	return reservation.UserID == guestID, nil
+}
+
+func (s *guestService) ResolveAccessToken(ctx context.Context, token string) (*model.Reservation, error) {
+	return s.resolve(ctx, token)
+}
+
+func (s *guestService) OnAccessIssued(listener GuestAccessListener) {
+	s.accessListeners = append(s.accessListeners, listener)
+}
+
+// findOrCreateGuest memakai ulang akun guest dengan email yang sama. Email
+// milik akun terdaftar ditolak agar guest tidak bisa memesan atas nama user lain.
+func (s *guestService) findOrCreateGuest(ctx context.Context, name, email string) (*userModel.User, error) {
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		user = &userModel.User{Name: name, Email: email, Role: userModel.RoleGuest}
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			// request paralel dengan email yang sama bisa lebih dulu membuat guest
+			if user, _ = s.userRepo.GetByEmail(ctx, email); user == nil {
+				return nil, err
+			}
+		}
+	}
+	if user.Role != userModel.RoleGuest {
+		return nil, ErrGuestEmailRegistered
+	}
+	return user, nil
+}
+
+// issueToken menyimpan token reservation; issued bernilai false jika token
+// sudah pernah dibuat sebelumnya.
+func (s *guestService) issueToken(ctx context.Context, reservationID uint) (token string, issued bool, err error) {
+	mac := hmac.New(sha256.New, s.tokenSecret)
+	mac.Write([]byte("guest-access:" + strconv.FormatUint(uint64(reservationID), 10)))
+	token = hex.EncodeToString(mac.Sum(nil))
+
+	issued, err = s.guestRepo.Create(ctx, &model.GuestAccessToken{
+		ReservationID: reservationID,
+		TokenHash:     hashAccessToken(token),
+		CreatedAt:     time.Now(),
+	})
+	if err != nil {
+		return "", false, err
+	}
+	return token, issued, nil
+}
+
+// resolve mengembalikan reservation milik token beserta user-nya, yang dipakai
+// sebagai actor untuk aksi guest.
+func (s *guestService) resolve(ctx context.Context, token string) (*model.Reservation, error) {
+	access, err := s.guestRepo.GetByTokenHash(ctx, hashAccessToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAccessToken
+		}
+		return nil, err
+	}
+	return s.reservationRepo.GetByID(ctx, access.ReservationID)
+}
+
+func hashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
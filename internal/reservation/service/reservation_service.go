@@ -2,90 +2,246 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"sync"
+	"slices"
+	"time"
 
+	"github.com/didanslmn/movie-reservation-system.git/config"
+	cinemaHall "github.com/didanslmn/movie-reservation-system.git/internal/cinemahall/repository"
+	pricing "github.com/didanslmn/movie-reservation-system.git/internal/pricing/service"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/request"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/response"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/mapper"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
 	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
 	seat "github.com/didanslmn/movie-reservation-system.git/internal/seat/repository"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
 	showtime "github.com/didanslmn/movie-reservation-system.git/internal/showtime/repository"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
 	users "github.com/didanslmn/movie-reservation-system.git/internal/users/repository"
 	"github.com/didanslmn/movie-reservation-system.git/utils"
 )
 
+// waitlistOfferReason menandai hold yang dibuat untuk antrean waitlist
+const waitlistOfferReason = "waitlist offer"
+
+// ExpireBatchSize membatasi jumlah reservation yang di-expire atau diselesaikan per putaran sweeper
+const ExpireBatchSize = 100
+
 type ReservationService interface {
-	CreateReservation(ctx context.Context, userID uint, req *request.CreateReservationRequest) (*response.ReservationResponse, error)
-	GetReservationByID(ctx context.Context, id uint) (*response.ReservationResponse, error)
-	GetAllReservations(ctx context.Context) ([]response.ReservationResponse, error)
-	DeleteReservation(ctx context.Context, id uint) error
-	ValidateSeatsAvailability(seatIDs []uint, showtimeID uint) error
+	CreateReservation(ctx context.Context, userID uint, req *request.CreateReservationRequest, idempotencyKey string) (*response.ReservationResponse, error)
+	GetReservationByID(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error)
+	GetAllReservations(ctx context.Context, actor *userModel.User, filter request.ReservationFilterRequest) ([]response.ReservationResponse, error)
+	GetUserReservations(ctx context.Context, userID uint, filter request.ReservationFilterRequest) ([]response.ReservationResponse, error)
+	DeleteReservation(ctx context.Context, actor *userModel.User, id uint) error
+	GetHoldStatus(ctx context.Context, actor *userModel.User, id uint) (*response.HoldResponse, error)
+	ExpirePendingReservations(ctx context.Context) (int, error)
+	CompleteFinishedReservations(ctx context.Context) (int, error)
+	ConfirmReservation(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error)
+	CancelReservation(ctx context.Context, actor *userModel.User, id uint, reason string) (*response.ReservationResponse, error)
+	CheckInReservation(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error)
+	RefundReservation(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error)
+	GetReservationHistory(ctx context.Context, actor *userModel.User, id uint) ([]response.ReservationEventResponse, error)
+	ModifyReservation(ctx context.Context, actor *userModel.User, id uint, req *request.UpdateReservationRequest) (*response.ReservationResponse, error)
+	HoldSeats(ctx context.Context, userID uint, showtimeID uint, count int) (*response.ReservationResponse, error)
+	SelectBestAvailable(ctx context.Context, userID uint, req *request.BestAvailableRequest) (*response.BestAvailableResponse, error)
+	CancelShowtimeReservations(ctx context.Context, actor *userModel.User, showtimeID uint, reason string) (cancelled int, refunded int, err error)
+	RescheduleShowtime(ctx context.Context, actor *userModel.User, previous, updated *showtimeModel.Showtime) (int, error)
+	AcceptReschedule(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error)
+	DeclineReschedule(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error)
+	OnSeatsReleased(listener SeatsReleasedListener)
+	OnSeatsChanged(listener SeatsChangedListener)
+	OnStatusChanged(listener StatusChangedListener)
+	OnRescheduled(listener StatusChangedListener)
+	PublishStatusChanged(id uint)
+}
+
+// SeatsReleasedListener dipanggil (di goroutine terpisah) setiap kali seat
+// sebuah showtime kembali tersedia karena pembatalan, expiry, atau perubahan.
+type SeatsReleasedListener func(ctx context.Context, showtimeID uint)
+
+// SeatChange adalah perubahan status seat di inventori satu showtime.
+// Status memakai nilai showtime_seats (available, held, booked).
+type SeatChange struct {
+	ShowtimeID uint
+	SeatIDs    []uint
+	Status     string
 }
 
+// SeatsChangedListener dipanggil langsung (tidak di goroutine) agar urutan
+// perubahan seat terjaga, sehingga listener tidak boleh blocking.
+type SeatsChangedListener func(ctx context.Context, change SeatChange)
+
+// StatusChangedListener dipanggil (di goroutine terpisah) setelah reservation
+// dibuat atau statusnya berubah, dengan data reservation lengkap.
+type StatusChangedListener func(ctx context.Context, r *model.Reservation)
+
+var (
+	ErrForbidden      = errors.New("reservation does not belong to user")
+	ErrHoldExpired    = errors.New("reservation hold has expired")
+	ErrCancelCutoff   = errors.New("cancellation window has closed")
+	ErrNotModifiable  = errors.New("only pending or confirmed reservations can be modified")
+	ErrDifferentMovie = errors.New("reservation can only be moved to a showtime of the same movie")
+	ErrNotEnoughSeats = errors.New("not enough available seats")
+
+	ErrNoPendingReschedule = errors.New("reservation has no pending schedule change")
+
+	ErrIdempotencyKeyReused   = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInFlight = errors.New("request with this idempotency key is still being processed")
+)
+
 type reservationService struct {
 	reservationRepo repository.ReservationRepository
+	idempotencyRepo repository.IdempotencyRepository
 	userRepo        users.UserRepository
 	showtimeRepo    showtime.ShowtimeRepository
 	seatRepo        seat.SeatRepository
+	cinemaHallRepo  cinemaHall.CinemaHallRepository
+	limitSvc        BookingLimitService
+	pricingSvc      pricing.PricingService
+	cfg             config.ReservationConfig
+
+	releaseListeners    []SeatsReleasedListener
+	seatListeners       []SeatsChangedListener
+	statusListeners     []StatusChangedListener
+	rescheduleListeners []StatusChangedListener
 }
 
 func NewReservationService(
 	reservationRepo repository.ReservationRepository,
+	idempotencyRepo repository.IdempotencyRepository,
 	userRepo users.UserRepository,
 	showtimeRepo showtime.ShowtimeRepository,
 	seatRepo seat.SeatRepository,
+	cinemaHallRepo cinemaHall.CinemaHallRepository,
+	limitSvc BookingLimitService,
+	pricingSvc pricing.PricingService,
+	cfg config.ReservationConfig,
 ) ReservationService {
 	return &reservationService{
 		reservationRepo: reservationRepo,
+		idempotencyRepo: idempotencyRepo,
 		userRepo:        userRepo,
 		showtimeRepo:    showtimeRepo,
 		seatRepo:        seatRepo,
+		cinemaHallRepo:  cinemaHallRepo,
+		limitSvc:        limitSvc,
+		pricingSvc:      pricingSvc,
+		cfg:             cfg,
 	}
 }
 
-func (s *reservationService) CreateReservation(ctx context.Context, userID uint, req *request.CreateReservationRequest) (*response.ReservationResponse, error) {
-	if exist, err := s.userRepo.ExistsByID(ctx, userID); err != nil || !exist {
-		utils.ErrorLogger.Printf("User not found (ID: %d): %v", userID, err)
-		return nil, fmt.Errorf("user not found")
+// CreateReservation membuat reservation baru. Jika idempotencyKey diisi,
+// request ulang dengan key dan body yang sama mengembalikan response awal.
+func (s *reservationService) CreateReservation(ctx context.Context, userID uint, req *request.CreateReservationRequest, idempotencyKey string) (*response.ReservationResponse, error) {
+	if idempotencyKey == "" {
+		return s.createReservation(ctx, userID, req, nil, 0)
 	}
-	err := s.ValidateSeatsAvailability(req.SeatIDs, req.ShowtimeID)
+
+	hash, err := hashCreateRequest(req)
 	if err != nil {
 		return nil, err
 	}
+	record := &model.IdempotencyKey{UserID: userID, Key: idempotencyKey, RequestHash: hash}
+	claimed, existing, err := s.idempotencyRepo.Claim(ctx, record, time.Now().Add(-s.cfg.IdempotencyTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		if existing.RequestHash == hash && len(existing.Response) == 0 && existing.ReservationID != nil {
+			return s.replayLinkedReservation(ctx, existing)
+		}
+		return replayIdempotentResponse(existing, hash)
+	}
+	record = existing
+
+	res, err := s.createReservation(ctx, userID, req, nil, record.ID)
+	if err != nil {
+		// key yang belum terhubung ke reservation dilepas agar retry bisa mencoba lagi
+		if delErr := s.idempotencyRepo.Delete(ctx, record.ID); delErr != nil {
+			utils.ErrorLogger.Printf("Failed to release idempotency key %q: %v", idempotencyKey, delErr)
+		}
+		return nil, err
+	}
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode reservation response: %w", err)
+	}
+	// reservation sudah terhubung ke key, jadi tanpa response tersimpan pun
+	// retry memutar ulang reservation yang sama
+	if err := s.idempotencyRepo.SaveResponse(ctx, record.ID, res.ID, body); err != nil {
+		utils.ErrorLogger.Printf("Failed to store response for idempotency key %q: %v", idempotencyKey, err)
+	}
+	return res, nil
+}
+
+// createReservation membuat reservation pending untuk userID. event nil berarti
+// reservation dibuat oleh user itu sendiri. idempotencyKeyID selain 0
+// dihubungkan ke reservation di transaksi yang sama.
+func (s *reservationService) createReservation(ctx context.Context, userID uint, req *request.CreateReservationRequest, event *model.ReservationEvent, idempotencyKeyID uint) (*response.ReservationResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		utils.ErrorLogger.Printf("User not found (ID: %d): %v", userID, err)
+		return nil, fmt.Errorf("user not found")
+	}
+	if event == nil {
+		event = auditEvent(user, "")
+	}
 
 	showtime, err := s.showtimeRepo.GetByID(ctx, req.ShowtimeID)
 	if err != nil {
 		utils.ErrorLogger.Printf("Showtime not found (ID: %d): %v", req.ShowtimeID, err)
 		return nil, fmt.Errorf("showtime not found")
 	}
+	if showtime.Status == showtimeModel.StatusCancelled {
+		return nil, showtimeModel.ErrShowtimeCancelled
+	}
+	if !showtime.StartTime.After(time.Now()) {
+		utils.ErrorLogger.Printf("Showtime already started (ID: %d)", req.ShowtimeID)
+		return nil, repository.ErrShowtimeStarted
+	}
+
+	seatIDs := normalizeSeatIDs(req.SeatIDs)
+	seats, err := s.loadSeats(ctx, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	guard, err := bookingGuard(ctx, s.limitSvc, userID, seatModel.TotalCapacity(seats), false)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	if guard.SeatGaps, err = s.seatGapGuard(ctx, showtime, seatIDs, &warnings); err != nil {
+		return nil, err
+	}
+
+	// seat ditahan selama hold window, tapi tidak melewati jadwal mulai showtime
+	expiredAt := capHold(time.Now().Add(s.cfg.HoldDuration), showtime)
 
-	seats, err := s.seatRepo.GetByIDs(ctx, req.SeatIDs)
-	if err != nil || len(seats) != len(req.SeatIDs) {
-		utils.ErrorLogger.Printf("Some seats not found or invalid: %v", err)
-		return nil, fmt.Errorf("one or more seats not found")
+	prices, total, err := s.quoteSeats(ctx, showtime, seats, req.TicketTypes)
+	if err != nil {
+		return nil, err
 	}
 
 	reservation := &model.Reservation{
 		UserID:     userID,
 		ShowtimeID: req.ShowtimeID,
-		Status:     "pending",
-		ExpiredAt:  showtime.EndTime,
-		Seats:      seats,
+		Status:     model.StatusPending,
+		ExpiredAt:  expiredAt,
+		TotalPrice: total,
+		SeatPrices: prices,
 	}
 
-	if err := s.reservationRepo.Create(ctx, reservation); err != nil {
+	if err := s.reservationRepo.Create(ctx, reservation, seatIDs, event, guard, idempotencyKeyID); err != nil {
 		utils.ErrorLogger.Printf("Failed to create reservation: %v", err)
 		return nil, fmt.Errorf("failed to create reservation: %w", err)
 	}
-	for _, seatID := range req.SeatIDs {
-		if err := s.seatRepo.UpdateStatus(ctx, seatID, "booked"); err != nil {
-			utils.ErrorLogger.Printf("Failed to update seat status (ID: %d): %v", seatID, err)
-			return nil, fmt.Errorf("failed to update seat status")
-		}
-	}
 
 	// Fetch lengkap untuk response
 	createdReservation, err := s.reservationRepo.GetByID(ctx, reservation.ID)
@@ -93,88 +249,691 @@ func (s *reservationService) CreateReservation(ctx context.Context, userID uint,
 		utils.ErrorLogger.Printf("Failed to fetch created reservation: %v", err)
 		return nil, fmt.Errorf("failed to fetch created reservation")
 	}
-	for _, seatID := range req.SeatIDs {
-		err := s.seatRepo.UpdateStatus(ctx, seatID, "booked")
-		if err != nil {
-			utils.ErrorLogger.Printf("Failed to update seat status (ID: %d): %v", seatID, err)
-			return nil, fmt.Errorf("failed to update seat status")
-		}
-	}
 
 	utils.InfoLogger.Printf("Reservation created: %+v", createdReservation)
-	return mapper.ToReservationResponse(createdReservation), nil
+	s.publishSeatsChanged(reservation.ShowtimeID, seatIDs, seatModel.ShowtimeSeatHeld)
+	// hold waitlist diumumkan lewat listener waitlist agar user tidak menerima dua email
+	if event.Reason != waitlistOfferReason {
+		s.publishStatusChanged(createdReservation)
+	}
+	res := mapper.ToReservationResponse(createdReservation)
+	res.Warnings = warnings
+	return res, nil
 }
 
-func (s *reservationService) GetReservationByID(ctx context.Context, id uint) (*response.ReservationResponse, error) {
-	res, err := s.reservationRepo.GetByID(ctx, id)
+func (s *reservationService) GetReservationByID(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error) {
+	res, err := s.getOwnedReservation(ctx, actor, id)
 	if err != nil {
-		utils.ErrorLogger.Printf("Failed to get reservation by ID %d: %v", id, err)
 		return nil, err
 	}
-	if res == nil {
-		utils.ErrorLogger.Printf("Reservation not found (id: %d)", id)
-		return nil, fmt.Errorf("reservation not found")
-	}
 	utils.InfoLogger.Printf("Fetched reservation by ID %d", id)
 	return mapper.ToReservationResponse(res), nil
 }
 
-func (s *reservationService) GetAllReservations(ctx context.Context) ([]response.ReservationResponse, error) {
-	reservations, err := s.reservationRepo.GetAll(ctx)
+// GetAllReservations mengembalikan semua reservation untuk admin, sedangkan
+// user biasa hanya melihat reservation miliknya sendiri.
+func (s *reservationService) GetAllReservations(ctx context.Context, actor *userModel.User, filter request.ReservationFilterRequest) ([]response.ReservationResponse, error) {
+	if actor.Role != userModel.RoleAdmin {
+		filter.UserID = actor.ID
+	}
+	return s.listReservations(ctx, filter)
+}
+
+// GetUserReservations adalah riwayat reservation milik satu user.
+func (s *reservationService) GetUserReservations(ctx context.Context, userID uint, filter request.ReservationFilterRequest) ([]response.ReservationResponse, error) {
+	filter.UserID = userID
+	return s.listReservations(ctx, filter)
+}
+
+func (s *reservationService) listReservations(ctx context.Context, filter request.ReservationFilterRequest) ([]response.ReservationResponse, error) {
+	repoFilter := repository.ReservationFilter{
+		UserID: filter.UserID,
+		Status: filter.Status,
+		From:   filter.From,
+	}
+	if filter.To != nil {
+		// tanggal "to" ikut dihitung sampai akhir hari
+		to := filter.To.AddDate(0, 0, 1)
+		repoFilter.To = &to
+	}
+
+	reservations, err := s.reservationRepo.GetAll(ctx, repoFilter)
 	if err != nil {
 		utils.ErrorLogger.Printf("Failed to get all reservations: %v", err)
 		return nil, err
 	}
-	utils.InfoLogger.Printf("Fetched all reservations, total: %d", len(reservations))
+	utils.InfoLogger.Printf("Fetched reservations (user filter: %d), total: %d", filter.UserID, len(reservations))
 	return mapper.ToReservationResponseList(reservations), nil
 }
 
-func (s *reservationService) DeleteReservation(ctx context.Context, id uint) error {
-	if err := s.reservationRepo.Delete(ctx, id); err != nil {
-		utils.ErrorLogger.Printf("Failed to delete reservation (ID: %d): %v", id, err)
+// getOwnedReservation mengambil reservation dan memastikan actor berhak melihatnya.
+func (s *reservationService) getOwnedReservation(ctx context.Context, actor *userModel.User, id uint) (*model.Reservation, error) {
+	res, err := s.reservationRepo.GetByID(ctx, id)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to get reservation by ID %d: %v", id, err)
+		return nil, err
+	}
+	if err := checkOwner(actor, res); err != nil {
+		utils.ErrorLogger.Printf("User %d is not allowed to access reservation %d", actor.ID, id)
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteReservation dipakai admin; reservation tidak dihapus, melainkan
+// dibatalkan agar seat dilepas dan riwayat tetap tersimpan.
+func (s *reservationService) DeleteReservation(ctx context.Context, actor *userModel.User, id uint) error {
+	if _, err := s.CancelReservation(ctx, actor, id, "cancelled by admin"); err != nil {
 		return err
 	}
-	utils.InfoLogger.Printf("Reservation deleted (ID: %d)", id)
+	utils.InfoLogger.Printf("Reservation cancelled by admin (ID: %d)", id)
 	return nil
 }
 
-func (s *reservationService) ValidateSeatsAvailability(seatIDs []uint, showtimeID uint) error {
-	type SeatCheckResult struct {
-		SeatID uint
-		Err    error
+func (s *reservationService) GetHoldStatus(ctx context.Context, actor *userModel.User, id uint) (*response.HoldResponse, error) {
+	res, err := s.getOwnedReservation(ctx, actor, id)
+	if err != nil {
+		return nil, err
 	}
+	return mapper.ToHoldResponse(res, time.Now()), nil
+}
 
-	resultChan := make(chan SeatCheckResult, len(seatIDs))
-	var wg sync.WaitGroup
+func (s *reservationService) ExpirePendingReservations(ctx context.Context) (int, error) {
+	expired, err := s.reservationRepo.ExpirePending(ctx, time.Now(), ExpireBatchSize)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to expire pending reservations: %v", err)
+		return 0, err
+	}
+	showtimes := make(map[uint]bool)
+	for _, r := range expired {
+		utils.InfoLogger.Printf("Reservation expired (ID: %d, showtime: %d)", r.ID, r.ShowtimeID)
+		showtimes[r.ShowtimeID] = true
+		s.publishSeatsChanged(r.ShowtimeID, reservationSeatIDs(&r), seatModel.ShowtimeSeatAvailable)
+		s.publishByID(r.ID, s.statusListeners)
+	}
+	for showtimeID := range showtimes {
+		s.publishSeatsReleased(showtimeID)
+	}
+	return len(expired), nil
+}
 
-	for _, seatID := range seatIDs {
-		wg.Add(1)
+// CompleteFinishedReservations menutup reservation yang showtime-nya sudah selesai.
+func (s *reservationService) CompleteFinishedReservations(ctx context.Context) (int, error) {
+	finished, err := s.reservationRepo.CompleteFinished(ctx, time.Now(), ExpireBatchSize)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to complete finished reservations: %v", err)
+		return 0, err
+	}
+	for _, r := range finished {
+		utils.InfoLogger.Printf("Reservation %s (ID: %d, showtime: %d)", r.Status, r.ID, r.ShowtimeID)
+		s.publishByID(r.ID, s.statusListeners)
+	}
+	return len(finished), nil
+}
 
-		go func(seatID uint) {
-			defer wg.Done()
+func (s *reservationService) ConfirmReservation(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error) {
+	return s.transition(ctx, id, model.ActionConfirm, auditEvent(actor, ""), func(r *model.Reservation) error {
+		if err := checkOwner(actor, r); err != nil {
+			return err
+		}
+		if !r.ExpiredAt.After(time.Now()) {
+			return ErrHoldExpired
+		}
+		return nil
+	})
+}
 
-			available, err := s.seatRepo.IsSeatAvailable(seatID, showtimeID)
-			if err != nil {
-				resultChan <- SeatCheckResult{SeatID: seatID, Err: err}
-				return
+func (s *reservationService) CancelReservation(ctx context.Context, actor *userModel.User, id uint, reason string) (*response.ReservationResponse, error) {
+	return s.transition(ctx, id, model.ActionCancel, auditEvent(actor, reason), func(r *model.Reservation) error {
+		if err := checkOwner(actor, r); err != nil {
+			return err
+		}
+		// admin boleh membatalkan kapan saja, user hanya sebelum cutoff
+		deadline := r.Showtime.StartTime.Add(-s.cfg.CancelCutoff)
+		if actor.Role != userModel.RoleAdmin && time.Now().After(deadline) {
+			return fmt.Errorf("%w: cancellation allowed until %s", ErrCancelCutoff, deadline.Format(time.RFC3339))
+		}
+
+		markCancelled(r, actor, reason)
+		return nil
+	})
+}
+
+// markCancelled mengisi data pembatalan sebelum status reservation diubah.
+func markCancelled(r *model.Reservation, actor *userModel.User, reason string) {
+	now := time.Now()
+	r.CancelledAt = &now
+	r.CancelledBy = &actor.ID
+	r.CancelReason = reason
+}
+
+func (s *reservationService) CheckInReservation(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error) {
+	return s.transition(ctx, id, model.ActionCheckIn, auditEvent(actor, ""), nil)
+}
+
+func (s *reservationService) RefundReservation(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error) {
+	return s.transition(ctx, id, model.ActionRefund, auditEvent(actor, ""), nil)
+}
+
+// GetReservationHistory mengembalikan audit trail reservation untuk pemilik atau admin.
+func (s *reservationService) GetReservationHistory(ctx context.Context, actor *userModel.User, id uint) ([]response.ReservationEventResponse, error) {
+	if _, err := s.getOwnedReservation(ctx, actor, id); err != nil {
+		return nil, err
+	}
+	events, err := s.reservationRepo.GetEvents(ctx, id)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to get history of reservation %d: %v", id, err)
+		return nil, err
+	}
+	return mapper.ToReservationEventResponseList(events), nil
+}
+
+func (s *reservationService) transition(ctx context.Context, id uint, action string, event *model.ReservationEvent, apply func(r *model.Reservation) error) (*response.ReservationResponse, error) {
+	updated, err := s.reservationRepo.Transition(ctx, id, action, event, apply)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to %s reservation (ID: %d): %v", action, id, err)
+		return nil, err
+	}
+	return s.afterTransition(ctx, updated, action)
+}
+
+// cancelAndRefund membatalkan reservation dan me-refund-nya dalam satu
+// transaksi jika reservation sudah pernah dibayar.
+func (s *reservationService) cancelAndRefund(ctx context.Context, id uint, event *model.ReservationEvent, apply func(r *model.Reservation) error) (*response.ReservationResponse, error) {
+	updated, err := s.reservationRepo.CancelAndRefund(ctx, id, event, apply)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to cancel reservation (ID: %d): %v", id, err)
+		return nil, err
+	}
+	return s.afterTransition(ctx, updated, model.ActionCancel)
+}
+
+// afterTransition memberi tahu listener setelah aksi state machine tersimpan.
+// action adalah aksi yang menentukan perubahan status seat.
+func (s *reservationService) afterTransition(ctx context.Context, updated *model.Reservation, action string) (*response.ReservationResponse, error) {
+	id := updated.ID
+	if action == model.ActionCancel || action == model.ActionExpire {
+		s.publishSeatsReleased(updated.ShowtimeID)
+	}
+
+	res, err := s.reservationRepo.GetByID(ctx, id)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to fetch reservation (ID: %d): %v", id, err)
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Reservation %d: %s -> %s", id, action, res.Status)
+	switch action {
+	case model.ActionConfirm:
+		s.publishSeatsChanged(res.ShowtimeID, reservationSeatIDs(res), seatModel.ShowtimeSeatBooked)
+	case model.ActionCancel, model.ActionExpire:
+		s.publishSeatsChanged(res.ShowtimeID, reservationSeatIDs(res), seatModel.ShowtimeSeatAvailable)
+	}
+	s.publishStatusChanged(res)
+	return mapper.ToReservationResponse(res), nil
+}
+
+// auditEvent menyiapkan event audit trail dengan actor dan alasan perubahan.
+// actor nil berarti perubahan dilakukan sistem.
+func auditEvent(actor *userModel.User, reason string) *model.ReservationEvent {
+	event := &model.ReservationEvent{Reason: reason}
+	if actor != nil {
+		event.ActorID = &actor.ID
+		event.ActorRole = string(actor.Role)
+	}
+	return event
+}
+
+// checkOwner memastikan reservation milik actor, kecuali actor adalah admin.
+func checkOwner(actor *userModel.User, r *model.Reservation) error {
+	if actor.Role == userModel.RoleAdmin || actor.ID == r.UserID {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// hashCreateRequest membuat hash dari isi request yang sudah dinormalisasi,
+// sehingga urutan seat yang berbeda tetap dianggap request yang sama.
+func hashCreateRequest(req *request.CreateReservationRequest) (string, error) {
+	body, err := json.Marshal(request.CreateReservationRequest{
+		ShowtimeID:  req.ShowtimeID,
+		SeatIDs:     normalizeSeatIDs(req.SeatIDs),
+		TicketTypes: req.TicketTypes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// capHold membatasi batas hold agar tidak melewati jadwal mulai showtime.
+func capHold(expiredAt time.Time, showtime *showtimeModel.Showtime) time.Time {
+	if expiredAt.After(showtime.StartTime) {
+		return showtime.StartTime
+	}
+	return expiredAt
+}
+
+func replayIdempotentResponse(existing *model.IdempotencyKey, hash string) (*response.ReservationResponse, error) {
+	if existing.RequestHash != hash {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if len(existing.Response) == 0 {
+		return nil, ErrIdempotencyKeyInFlight
+	}
+
+	var res response.ReservationResponse
+	if err := json.Unmarshal(existing.Response, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+	utils.InfoLogger.Printf("Replayed idempotent reservation response (ID: %d)", res.ID)
+	return &res, nil
+}
+
+// replayLinkedReservation memutar ulang reservation yang sudah dibuat untuk key
+// tetapi response-nya belum tersimpan (proses crash atau SaveResponse gagal).
+func (s *reservationService) replayLinkedReservation(ctx context.Context, record *model.IdempotencyKey) (*response.ReservationResponse, error) {
+	reservation, err := s.reservationRepo.GetByID(ctx, *record.ReservationID)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to fetch reservation for idempotency key (ID: %d): %v", record.ID, err)
+		return nil, fmt.Errorf("failed to fetch reservation: %w", err)
+	}
+	res := mapper.ToReservationResponse(reservation)
+	if body, err := json.Marshal(res); err == nil {
+		if err := s.idempotencyRepo.SaveResponse(ctx, record.ID, reservation.ID, body); err != nil {
+			utils.ErrorLogger.Printf("Failed to store response for idempotency key (ID: %d): %v", record.ID, err)
+		}
+	}
+	utils.InfoLogger.Printf("Replayed linked reservation for idempotency key (ID: %d)", record.ID)
+	return res, nil
+}
+
+// ModifyReservation mengganti seat atau memindahkan reservation ke showtime lain
+// dari film yang sama dengan aturan batas waktu yang sama seperti pembatalan.
+func (s *reservationService) ModifyReservation(ctx context.Context, actor *userModel.User, id uint, req *request.UpdateReservationRequest) (*response.ReservationResponse, error) {
+	seatIDs := normalizeSeatIDs(req.SeatIDs)
+
+	current, err := s.getOwnedReservation(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	seats, err := s.loadSeats(ctx, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	guard, err := bookingGuard(ctx, s.limitSvc, current.UserID, seatModel.TotalCapacity(seats), true)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *showtimeModel.Showtime
+	if req.ShowtimeID != 0 {
+		showtime, err := s.showtimeRepo.GetByID(ctx, req.ShowtimeID)
+		if err != nil {
+			utils.ErrorLogger.Printf("Showtime not found (ID: %d): %v", req.ShowtimeID, err)
+			return nil, fmt.Errorf("showtime not found")
+		}
+		if showtime.Status == showtimeModel.StatusCancelled {
+			return nil, showtimeModel.ErrShowtimeCancelled
+		}
+		if !showtime.StartTime.After(time.Now()) {
+			return nil, repository.ErrShowtimeStarted
+		}
+		target = showtime
+	}
+
+	checkShowtime := &current.Showtime
+	if target != nil {
+		checkShowtime = target
+	}
+	var warnings []string
+	if guard.SeatGaps, err = s.seatGapGuard(ctx, checkShowtime, seatIDs, &warnings); err != nil {
+		return nil, err
+	}
+
+	ticketTypes := req.TicketTypes
+	if len(ticketTypes) == 0 {
+		ticketTypes = carryTicketTypes(current.SeatPrices, seatIDs)
+	}
+	quoteShowtime := &current.Showtime
+	if target != nil {
+		quoteShowtime = target
+	}
+	prices, total, err := s.quoteSeats(ctx, quoteShowtime, seats, ticketTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	previousShowtimeID := current.ShowtimeID
+	_, err = s.reservationRepo.Modify(ctx, id, req.ShowtimeID, seatIDs, auditEvent(actor, ""), guard, func(r *model.Reservation) error {
+		if err := checkOwner(actor, r); err != nil {
+			return err
+		}
+		if r.Status != model.StatusPending && r.Status != model.StatusConfirmed {
+			return ErrNotModifiable
+		}
+		if r.Status == model.StatusPending && !r.ExpiredAt.After(time.Now()) {
+			return ErrHoldExpired
+		}
+		deadline := r.Showtime.StartTime.Add(-s.cfg.CancelCutoff)
+		if actor.Role != userModel.RoleAdmin && time.Now().After(deadline) {
+			return fmt.Errorf("%w: changes allowed until %s", ErrCancelCutoff, deadline.Format(time.RFC3339))
+		}
+		if target != nil && target.MovieID != r.Showtime.MovieID {
+			return ErrDifferentMovie
+		}
+
+		oldSeatIDs := make([]uint, 0, len(r.Seats))
+		for _, seat := range r.Seats {
+			oldSeatIDs = append(oldSeatIDs, seat.ID)
+		}
+		newShowtimeID := r.ShowtimeID
+		if target != nil {
+			newShowtimeID = target.ID
+		}
+
+		now := time.Now()
+		r.SeatPrices = prices
+		r.TotalPrice = total
+		r.ModifiedAt = &now
+		r.ModificationCount++
+		r.ModificationNote = fmt.Sprintf("showtime %d -> %d, seats %v -> %v", r.ShowtimeID, newShowtimeID, oldSeatIDs, seatIDs)
+		if target != nil {
+			// hold di showtime baru tetap tidak boleh melewati jadwal mulainya
+			r.ExpiredAt = capHold(r.ExpiredAt, target)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to modify reservation (ID: %d): %v", id, err)
+		return nil, err
+	}
+
+	res, err := s.reservationRepo.GetByID(ctx, id)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to fetch reservation (ID: %d): %v", id, err)
+		return nil, err
+	}
+	// seat lama (di showtime lama atau yang tidak dipilih lagi) sudah dilepas
+	s.publishSeatsReleased(previousShowtimeID)
+	s.publishSeatsModified(current, res)
+	utils.InfoLogger.Printf("Reservation modified (ID: %d): %s", id, res.ModificationNote)
+	modified := mapper.ToReservationResponse(res)
+	modified.Warnings = warnings
+	return modified, nil
+}
+
+// loadSeats mengambil seat yang dipilih beserta kategorinya.
+func (s *reservationService) loadSeats(ctx context.Context, seatIDs []uint) ([]seatModel.Seat, error) {
+	seats, err := s.seatRepo.GetByIDs(ctx, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(seats) != len(seatIDs) {
+		return nil, repository.ErrSeatNotInShowtime
+	}
+	return seats, nil
+}
+
+// quoteSeats menghitung harga seat yang dipilih untuk disimpan bersama reservation.
+func (s *reservationService) quoteSeats(ctx context.Context, showtime *showtimeModel.Showtime, seats []seatModel.Seat, ticketTypes map[uint]string) ([]model.ReservationSeat, int64, error) {
+	quotes, err := s.pricingSvc.QuoteSeats(ctx, showtime, seats, ticketTypes)
+	if err != nil {
+		return nil, 0, err
+	}
+	prices := make([]model.ReservationSeat, 0, len(quotes))
+	var total int64
+	for _, q := range quotes {
+		prices = append(prices, model.ReservationSeat{
+			SeatID:         q.SeatID,
+			TicketType:     q.TicketType,
+			Price:          q.Price,
+			PriceBreakdown: q.Breakdown.String(),
+		})
+		total += q.Price
+	}
+	return prices, total, nil
+}
+
+// carryTicketTypes mempertahankan jenis tiket saat seat diganti: seat yang
+// tetap dipilih memakai jenis lamanya, seat baru mendapat sisa jenis tiket lama.
+func carryTicketTypes(previous []model.ReservationSeat, seatIDs []uint) map[uint]string {
+	types := make(map[uint]string, len(seatIDs))
+	var leftover []string
+	for _, p := range previous {
+		if p.TicketType == "" {
+			continue
+		}
+		if slices.Contains(seatIDs, p.SeatID) {
+			types[p.SeatID] = p.TicketType
+		} else {
+			leftover = append(leftover, p.TicketType)
+		}
+	}
+	for _, id := range seatIDs {
+		if _, ok := types[id]; !ok && len(leftover) > 0 {
+			types[id] = leftover[0]
+			leftover = leftover[1:]
+		}
+	}
+	return types
+}
+
+// normalizeSeatIDs mengurutkan dan menghapus seat ID duplikat.
+func normalizeSeatIDs(ids []uint) []uint {
+	seatIDs := slices.Clone(ids)
+	slices.Sort(seatIDs)
+	return slices.Compact(seatIDs)
+}
+
+// bookingGuard menyiapkan pengecekan batas booking user. Batas dihitung dalam
+// kapasitas (jumlah orang), jadi couple seat dihitung dua. Batas seat per
+// reservation dicek langsung; batas yang bergantung pada reservation lain user
+// dicek repository di dalam transaksi booking agar request paralel tidak bisa
+// sama-sama lolos. Untuk perubahan reservation (modifying), jumlah reservation
+// aktif tidak bertambah sehingga tidak dicek. Dipakai juga saat transfer
+// diterima, untuk batas booking penerima.
+func bookingGuard(ctx context.Context, limitSvc BookingLimitService, userID uint, seatCount int, modifying bool) (*repository.BookingGuard, error) {
+	limit, err := limitSvc.GetEffectiveLimit(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if seatCount > limit.MaxSeatsPerReservation {
+		return nil, &LimitError{Limit: LimitSeatsPerReservation, Max: limit.MaxSeatsPerReservation, Requested: seatCount}
+	}
+
+	return &repository.BookingGuard{
+		Limits: func(active, held int64) error {
+			if int(held)+seatCount > limit.MaxSeatsPerShowtime {
+				return &LimitError{Limit: LimitSeatsPerShowtime, Max: limit.MaxSeatsPerShowtime, Current: int(held), Requested: seatCount}
 			}
-			if !available {
-				resultChan <- SeatCheckResult{SeatID: seatID, Err: errors.New("seat not available")}
-				return
+			if !modifying && int(active)+1 > limit.MaxActiveReservations {
+				return &LimitError{Limit: LimitActiveReservations, Max: limit.MaxActiveReservations, Current: int(active), Requested: 1}
 			}
+			return nil
+		},
+	}, nil
+}
+
+// HoldSeats menahan count seat terbaik yang tersedia untuk user, dipakai
+// misalnya untuk promosi waitlist. Hasilnya reservation pending biasa yang
+// akan expire sesuai hold window; status listeners tidak dipanggil karena
+// pemanggil yang mengirim notifikasinya sendiri.
+func (s *reservationService) HoldSeats(ctx context.Context, userID uint, showtimeID uint, count int) (*response.ReservationResponse, error) {
+	res, _, err := s.holdBestSeats(ctx, userID, showtimeID, count, auditEvent(nil, waitlistOfferReason))
+	return res, err
+}
+
+// SelectBestAvailable memilih seat terbaik untuk rombongan dan, jika diminta,
+// langsung menahannya sebagai reservation pending.
+func (s *reservationService) SelectBestAvailable(ctx context.Context, userID uint, req *request.BestAvailableRequest) (*response.BestAvailableResponse, error) {
+	if req.Hold {
+		res, together, err := s.holdBestSeats(ctx, userID, req.ShowtimeID, req.PartySize, nil)
+		if err != nil {
+			return nil, err
+		}
+		return &response.BestAvailableResponse{
+			ShowtimeID:  req.ShowtimeID,
+			Seats:       res.Seats,
+			Together:    together,
+			Reservation: res,
+		}, nil
+	}
+
+	seats, together, err := s.pickBestSeats(ctx, req.ShowtimeID, req.PartySize)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToBestAvailableResponse(req.ShowtimeID, seats, together), nil
+}
+
+// pickBestSeats memilih seat dari layout hall showtime dengan melihat
+// ketersediaan seat pada showtime tersebut.
+func (s *reservationService) pickBestSeats(ctx context.Context, showtimeID uint, count int) ([]seatModel.Seat, bool, error) {
+	showtime, err := s.showtimeRepo.GetByID(ctx, showtimeID)
+	if err != nil {
+		return nil, false, err
+	}
+	if showtime.Status == showtimeModel.StatusCancelled {
+		return nil, false, showtimeModel.ErrShowtimeCancelled
+	}
+	if !showtime.StartTime.After(time.Now()) {
+		return nil, false, repository.ErrShowtimeStarted
+	}
+
+	hallSeats, err := s.seatRepo.GetByHallID(ctx, showtime.CinemaHallID)
+	if err != nil {
+		return nil, false, err
+	}
+	available, err := s.seatRepo.GetAvailableSeats(ctx, showtimeID)
+	if err != nil {
+		return nil, false, err
+	}
+	free := make(map[uint]bool, len(available))
+	for _, seat := range available {
+		free[seat.ID] = true
+	}
+
+	seats, together, ok := selectBestSeats(hallSeats, free, count)
+	if !ok {
+		return nil, false, ErrNotEnoughSeats
+	}
+	return seats, together, nil
+}
+
+func (s *reservationService) holdBestSeats(ctx context.Context, userID uint, showtimeID uint, count int, event *model.ReservationEvent) (*response.ReservationResponse, bool, error) {
+	// seat bisa direbut pembeli lain di antara pemilihan dan penguncian, jadi coba beberapa kali
+	for attempt := 0; attempt < 3; attempt++ {
+		seats, together, err := s.pickBestSeats(ctx, showtimeID, count)
+		if err != nil {
+			return nil, false, err
+		}
+
+		seatIDs := make([]uint, 0, len(seats))
+		for _, seat := range seats {
+			seatIDs = append(seatIDs, seat.ID)
+		}
+		res, err := s.createReservation(ctx, userID, &request.CreateReservationRequest{ShowtimeID: showtimeID, SeatIDs: seatIDs}, event, 0)
+		if !errors.Is(err, repository.ErrSeatNotAvailable) {
+			return res, together, err
+		}
+	}
+	return nil, false, ErrNotEnoughSeats
+}
+
+func (s *reservationService) OnSeatsReleased(listener SeatsReleasedListener) {
+	s.releaseListeners = append(s.releaseListeners, listener)
+}
+
+func (s *reservationService) OnSeatsChanged(listener SeatsChangedListener) {
+	s.seatListeners = append(s.seatListeners, listener)
+}
 
-			resultChan <- SeatCheckResult{SeatID: seatID, Err: nil}
-		}(seatID)
+func (s *reservationService) publishSeatsChanged(showtimeID uint, seatIDs []uint, status string) {
+	if len(seatIDs) == 0 {
+		return
+	}
+	change := SeatChange{ShowtimeID: showtimeID, SeatIDs: seatIDs, Status: status}
+	for _, listener := range s.seatListeners {
+		listener(context.Background(), change)
 	}
+}
 
-	wg.Wait()
-	close(resultChan)
+// publishSeatsModified mengirim perubahan seat akibat ModifyReservation:
+// seat lama yang tidak dipakai lagi dilepas, seat baru ikut status reservation.
+func (s *reservationService) publishSeatsModified(before, after *model.Reservation) {
+	status := seatModel.ShowtimeSeatHeld
+	if after.Status == model.StatusConfirmed {
+		status = seatModel.ShowtimeSeatBooked
+	}
 
-	for result := range resultChan {
-		if result.Err != nil {
-			return errors.New("seat ID " + fmt.Sprint(result.SeatID) + " is not available or has an error: " + result.Err.Error())
+	oldIDs := reservationSeatIDs(before)
+	newIDs := reservationSeatIDs(after)
+	if before.ShowtimeID != after.ShowtimeID {
+		s.publishSeatsChanged(before.ShowtimeID, oldIDs, seatModel.ShowtimeSeatAvailable)
+		s.publishSeatsChanged(after.ShowtimeID, newIDs, status)
+		return
+	}
+
+	var released, added []uint
+	for _, id := range oldIDs {
+		if !slices.Contains(newIDs, id) {
+			released = append(released, id)
+		}
+	}
+	for _, id := range newIDs {
+		if !slices.Contains(oldIDs, id) {
+			added = append(added, id)
 		}
 	}
+	s.publishSeatsChanged(after.ShowtimeID, released, seatModel.ShowtimeSeatAvailable)
+	s.publishSeatsChanged(after.ShowtimeID, added, status)
+}
+
+func reservationSeatIDs(r *model.Reservation) []uint {
+	ids := make([]uint, 0, len(r.Seats))
+	for _, seat := range r.Seats {
+		ids = append(ids, seat.ID)
+	}
+	return ids
+}
 
-	return nil
+func (s *reservationService) publishSeatsReleased(showtimeID uint) {
+	for _, listener := range s.releaseListeners {
+		go listener(context.Background(), showtimeID)
+	}
+}
+
+func (s *reservationService) OnStatusChanged(listener StatusChangedListener) {
+	s.statusListeners = append(s.statusListeners, listener)
+}
+
+func (s *reservationService) publishStatusChanged(r *model.Reservation) {
+	for _, listener := range s.statusListeners {
+		go listener(context.Background(), r)
+	}
+}
+
+// PublishStatusChanged memanggil status listeners untuk perubahan status yang
+// disimpan di luar service ini (misalnya check-in tiket).
+func (s *reservationService) PublishStatusChanged(id uint) {
+	s.publishByID(id, s.statusListeners)
+}
+
+// publishByID memuat ulang reservation lengkap sebelum memanggil listeners,
+// untuk jalur yang hanya punya ID (misalnya sweeper).
+func (s *reservationService) publishByID(id uint, listeners []StatusChangedListener) {
+	if len(listeners) == 0 {
+		return
+	}
+	go func() {
+		r, err := s.reservationRepo.GetByID(context.Background(), id)
+		if err != nil {
+			return
+		}
+		for _, listener := range listeners {
+			listener(context.Background(), r)
+		}
+	}()
 }
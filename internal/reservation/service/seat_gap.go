@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cinemaHallModel "github.com/didanslmn/movie-reservation-system.git/internal/cinemahall/model"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+)
+
+// SeatGapRule menjelaskan aturan orphan seat ke client.
+const SeatGapRule = "a selection must not leave a single empty seat between occupied seats or between an occupied seat and the end of a row"
+
+type SeatGap struct {
+	SeatID     uint   `json:"seat_id"`
+	Row        string `json:"row"`
+	SeatNumber string `json:"seat_number"`
+}
+
+// SeatGapError dikembalikan saat pilihan seat menyisakan seat kosong tunggal
+// di hall dengan kebijakan reject. Pada kebijakan warn, pesannya menjadi warning.
+type SeatGapError struct {
+	Rule   string    `json:"rule"`
+	Policy string    `json:"policy"`
+	Gaps   []SeatGap `json:"gaps"`
+}
+
+func (e *SeatGapError) Error() string {
+	seats := make([]string, 0, len(e.Gaps))
+	for _, gap := range e.Gaps {
+		seats = append(seats, gap.SeatNumber)
+	}
+	return fmt.Sprintf("selection leaves single empty seat(s): %s", strings.Join(seats, ", "))
+}
+
+// seatGapGuard menyiapkan kebijakan orphan seat hall untuk seatIDs. Pengecekan
+// dijalankan repository di dalam transaksi booking terhadap seat showtime yang
+// sudah dikunci, sehingga booking paralel di sebelahnya tidak bisa lolos
+// bersamaan. Pada kebijakan warn, pesannya ditambahkan ke warnings. Hasil nil
+// berarti hall tidak memakai aturan orphan seat.
+func (s *reservationService) seatGapGuard(ctx context.Context, showtime *showtimeModel.Showtime, seatIDs []uint, warnings *[]string) (func(free map[uint]bool) error, error) {
+	hall, err := s.cinemaHallRepo.GetByID(ctx, showtime.CinemaHallID)
+	if err != nil {
+		return nil, err
+	}
+	if hall.SeatGapPolicy == cinemaHallModel.SeatGapPolicyOff {
+		return nil, nil
+	}
+
+	layouts := buildRowLayouts(hall.Seat)
+	return func(free map[uint]bool) error {
+		gapErr := checkSeatGaps(layouts, hall.SeatGapPolicy, free, seatIDs)
+		if gapErr == nil {
+			return nil
+		}
+		if hall.SeatGapPolicy == cinemaHallModel.SeatGapPolicyReject {
+			return gapErr
+		}
+		*warnings = append(*warnings, gapErr.Error())
+		return nil
+	}, nil
+}
+
+// checkSeatGaps mengembalikan SeatGapError jika seatIDs menyisakan seat kosong
+// tunggal. free adalah seat yang bisa dipesan, termasuk seat milik reservation
+// yang sedang diubah.
+func checkSeatGaps(layouts []rowLayout, policy string, free map[uint]bool, seatIDs []uint) *SeatGapError {
+	selected := make(map[uint]bool, len(seatIDs))
+	for _, id := range seatIDs {
+		selected[id] = true
+	}
+
+	orphans := orphanedSeats(layouts, free, selected)
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	gapErr := &SeatGapError{Rule: SeatGapRule, Policy: policy}
+	for _, seat := range orphans {
+		gapErr.Gaps = append(gapErr.Gaps, SeatGap{SeatID: seat.ID, Row: seat.Row, SeatNumber: seat.SeatNumber})
+	}
+	return gapErr
+}
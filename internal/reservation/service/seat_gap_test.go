@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	cinemaHallModel "github.com/didanslmn/movie-reservation-system.git/internal/cinemahall/model"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+)
+
+// hallSeats membuat seat hall standard dengan rows berisi jumlah seat per row
+// (row A, B, ...). ID seat adalah urutan row * 100 + nomor seat, misalnya B3 = 203.
+func hallSeats(rows ...int) []seatModel.Seat {
+	var seats []seatModel.Seat
+	for r, count := range rows {
+		for n := 1; n <= count; n++ {
+			seats = append(seats, newSeat(string(rune('A'+r)), n, seatModel.CategoryStandard, 1))
+		}
+	}
+	return seats
+}
+
+func newSeat(row string, number int, category string, capacity int) seatModel.Seat {
+	seat := seatModel.Seat{
+		Row:          row,
+		SeatNumber:   fmt.Sprint(number),
+		Status:       "available",
+		Category:     category,
+		CategoryInfo: &seatModel.SeatCategory{Name: category, Capacity: capacity},
+	}
+	seat.ID = seatID(row, number)
+	return seat
+}
+
+func seatID(row string, number int) uint {
+	return uint(int(row[0]-'A'+1)*100 + number)
+}
+
+// freeExcept mengembalikan semua seat hall sebagai free kecuali taken.
+func freeExcept(seats []seatModel.Seat, taken ...uint) map[uint]bool {
+	free := make(map[uint]bool, len(seats))
+	for _, seat := range seats {
+		if !slices.Contains(taken, seat.ID) {
+			free[seat.ID] = true
+		}
+	}
+	return free
+}
+
+func ids(seats []seatModel.Seat) []uint {
+	out := make([]uint, 0, len(seats))
+	for _, seat := range seats {
+		out = append(out, seat.ID)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func TestCheckSeatGaps(t *testing.T) {
+	a := func(n int) uint { return seatID("A", n) }
+	seats := hallSeats(6)
+
+	tests := []struct {
+		name     string
+		taken    []uint
+		selected []uint
+		want     []uint
+	}{
+		{name: "block at row end", selected: []uint{a(1), a(2)}},
+		{name: "single seat left at row end", selected: []uint{a(2), a(3)}, want: []uint{a(1)}},
+		{name: "single seat left between bookings", taken: []uint{a(1), a(2), a(3)}, selected: []uint{a(5)}, want: []uint{a(4), a(6)}},
+		{name: "filling a single gap", taken: []uint{a(1), a(2), a(3), a(5), a(6)}, selected: []uint{a(4)}},
+		{name: "existing orphan is not counted", taken: []uint{a(2)}, selected: []uint{a(5), a(6)}},
+		{name: "pair left between bookings", taken: []uint{a(1)}, selected: []uint{a(4), a(5), a(6)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gapErr := checkSeatGaps(buildRowLayouts(seats), cinemaHallModel.SeatGapPolicyReject, freeExcept(seats, tt.taken...), tt.selected)
+
+			var got []uint
+			if gapErr != nil {
+				for _, gap := range gapErr.Gaps {
+					got = append(got, gap.SeatID)
+				}
+				slices.Sort(got)
+				if gapErr.Policy != cinemaHallModel.SeatGapPolicyReject || gapErr.Rule != SeatGapRule {
+					t.Errorf("gap error = %+v, want reject policy with rule", gapErr)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("orphans = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrphanedSeatsRespectsAisles(t *testing.T) {
+	// seat 4 tidak ada (lorong), sehingga seat 3 dan 5 tidak bersebelahan
+	seats := []seatModel.Seat{
+		newSeat("A", 1, seatModel.CategoryStandard, 1),
+		newSeat("A", 2, seatModel.CategoryStandard, 1),
+		newSeat("A", 3, seatModel.CategoryStandard, 1),
+		newSeat("A", 5, seatModel.CategoryStandard, 1),
+		newSeat("A", 6, seatModel.CategoryStandard, 1),
+	}
+	selected := map[uint]bool{seatID("A", 1): true, seatID("A", 2): true, seatID("A", 6): true}
+
+	got := ids(orphanedSeats(buildRowLayouts(seats), freeExcept(seats), selected))
+	want := []uint{seatID("A", 3), seatID("A", 5)}
+	if !slices.Equal(got, want) {
+		t.Errorf("orphans = %v, want %v", got, want)
+	}
+}
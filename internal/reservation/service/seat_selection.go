@@ -0,0 +1,204 @@
+package service
+
+import (
+	"math"
+	"slices"
+
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+)
+
+// orphanPenalty lebih besar dari skor posisi terburuk (2), sehingga blok tanpa
+// orphan selalu diutamakan.
+const orphanPenalty = 10
+
+// rowLayout adalah seat satu row yang sudah diurutkan berdasarkan nomor seat.
+type rowLayout struct {
+	seats  []seatModel.Seat
+	nums   []int
+	centre float64
+}
+
+// buildRowLayouts mengelompokkan seat hall per row, urut dari depan ke belakang.
+func buildRowLayouts(hallSeats []seatModel.Seat) []rowLayout {
+	byRow := make(map[string][]seatModel.Seat)
+	rows := make([]string, 0)
+	for _, seat := range hallSeats {
+		if _, ok := byRow[seat.Row]; !ok {
+			rows = append(rows, seat.Row)
+		}
+		byRow[seat.Row] = append(byRow[seat.Row], seat)
+	}
+	slices.SortFunc(rows, seatModel.CompareRow)
+
+	layouts := make([]rowLayout, 0, len(rows))
+	for _, row := range rows {
+		seats := byRow[row]
+		slices.SortFunc(seats, func(a, b seatModel.Seat) int {
+			return seatModel.CompareSeatNumber(a.SeatNumber, b.SeatNumber)
+		})
+
+		// nomor yang tidak bisa dibaca memakai urutan di row
+		nums := make([]int, len(seats))
+		for i, seat := range seats {
+			n, ok := seatModel.SeatNumberValue(seat.SeatNumber)
+			if !ok {
+				n = i + 1
+			}
+			nums[i] = n
+		}
+		layouts = append(layouts, rowLayout{
+			seats:  seats,
+			nums:   nums,
+			centre: float64(nums[0]+nums[len(nums)-1]) / 2,
+		})
+	}
+	return layouts
+}
+
+// bestWindow mencari seat bersebelahan untuk size orang dengan skor terbaik:
+// makin dekat ke tengah row dan ke row tengah hall, makin baik. Kapasitas seat
+// dihitung, sehingga couple seat hanya dipakai jika pas untuk dua orang.
+func bestWindow(layouts []rowLayout, free map[uint]bool, size int) ([]seatModel.Seat, bool) {
+	middleRow := float64(len(layouts)-1) / 2
+	bestScore := math.Inf(1)
+	var best []seatModel.Seat
+
+	for rowIdx, layout := range layouts {
+		halfWidth := math.Max(1, float64(layout.nums[len(layout.nums)-1]-layout.nums[0])/2)
+		for start := range layout.seats {
+			end, capacity := start, 0
+			for end < len(layout.seats) && capacity < size {
+				capacity += layout.seats[end].Capacity()
+				end++
+			}
+			if capacity != size || !isFreeRun(layout, free, start, end-start) {
+				continue
+			}
+			mid := float64(layout.nums[start]+layout.nums[end-1]) / 2
+			score := math.Abs(mid-layout.centre)/halfWidth + math.Abs(float64(rowIdx)-middleRow)/math.Max(1, middleRow)
+			// blok yang menyisakan seat kosong tunggal hanya dipilih jika tidak ada pilihan lain
+			window := make(map[uint]bool, end-start)
+			for _, seat := range layout.seats[start:end] {
+				window[seat.ID] = true
+			}
+			if len(rowOrphans(layout, free, window)) > 0 {
+				score += orphanPenalty
+			}
+			if score < bestScore {
+				bestScore = score
+				best = layout.seats[start:end]
+			}
+		}
+	}
+	return best, best != nil
+}
+
+func isFreeRun(layout rowLayout, free map[uint]bool, start, size int) bool {
+	for i := start; i < start+size; i++ {
+		if !free[layout.seats[i].ID] {
+			return false
+		}
+		if i > start && layout.nums[i]-layout.nums[i-1] != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// selectBestSeats memilih seat untuk count orang dari seat hall yang masih
+// free. Seat bersebelahan dalam satu row diutamakan; jika tidak ada, rombongan
+// dipecah ke blok sebesar mungkin. together bernilai false jika rombongan dipecah.
+func selectBestSeats(hallSeats []seatModel.Seat, free map[uint]bool, count int) (selected []seatModel.Seat, together bool, ok bool) {
+	if count <= 0 || !canSeat(hallSeats, free, count) {
+		return nil, false, false
+	}
+	layouts := buildRowLayouts(hallSeats)
+
+	remaining := make(map[uint]bool, len(free))
+	for id := range free {
+		remaining[id] = true
+	}
+
+	blocks := 0
+	for left := count; left > 0; {
+		found := false
+		for size := left; size >= 1; size-- {
+			block, ok := bestWindow(layouts, remaining, size)
+			if !ok {
+				continue
+			}
+			for _, seat := range block {
+				delete(remaining, seat.ID)
+			}
+			// blok tidak dipakai jika sisa rombongan tidak bisa lagi didudukkan pas,
+			// misalnya satu orang tersisa sementara seat kosong hanya couple seat
+			if !canSeat(hallSeats, remaining, left-size) {
+				for _, seat := range block {
+					remaining[seat.ID] = true
+				}
+				continue
+			}
+			selected = append(selected, block...)
+			blocks++
+			left -= size
+			found = true
+			break
+		}
+		if !found {
+			return nil, false, false
+		}
+	}
+	return selected, blocks == 1, true
+}
+
+// canSeat memeriksa apakah ada kombinasi seat free yang kapasitasnya tepat
+// count orang (subset sum atas kapasitas seat).
+func canSeat(hallSeats []seatModel.Seat, free map[uint]bool, count int) bool {
+	reachable := make([]bool, count+1)
+	reachable[0] = true
+	for _, seat := range hallSeats {
+		if !free[seat.ID] {
+			continue
+		}
+		c := seat.Capacity()
+		for sum := count; sum >= c; sum-- {
+			if reachable[sum-c] {
+				reachable[sum] = true
+			}
+		}
+	}
+	return reachable[count]
+}
+
+// orphanedSeats mengembalikan seat kosong yang menjadi tunggal setelah selected
+// terisi: kedua sisinya terisi (atau ujung row/lorong) dan minimal satu sisinya
+// adalah seat yang baru dipilih. Orphan yang sudah ada sebelumnya tidak dihitung.
+func orphanedSeats(layouts []rowLayout, free map[uint]bool, selected map[uint]bool) []seatModel.Seat {
+	var orphans []seatModel.Seat
+	for _, layout := range layouts {
+		orphans = append(orphans, rowOrphans(layout, free, selected)...)
+	}
+	return orphans
+}
+
+func rowOrphans(layout rowLayout, free map[uint]bool, selected map[uint]bool) []seatModel.Seat {
+	var orphans []seatModel.Seat
+	isOpen := func(i int) bool {
+		id := layout.seats[i].ID
+		return free[id] && !selected[id]
+	}
+	for i := range layout.seats {
+		if !isOpen(i) {
+			continue
+		}
+		hasLeft := i > 0 && layout.nums[i]-layout.nums[i-1] == 1
+		hasRight := i < len(layout.seats)-1 && layout.nums[i+1]-layout.nums[i] == 1
+		if (hasLeft && isOpen(i-1)) || (hasRight && isOpen(i+1)) {
+			continue
+		}
+		if (hasLeft && selected[layout.seats[i-1].ID]) || (hasRight && selected[layout.seats[i+1].ID]) {
+			orphans = append(orphans, layout.seats[i])
+		}
+	}
+	return orphans
+}
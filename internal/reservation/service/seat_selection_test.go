@@ -0,0 +1,229 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+)
+
+// rowSeats mengembalikan ID seat row dari nomor from sampai to.
+func rowSeats(row string, from, to int) []uint {
+	var out []uint
+	for n := from; n <= to; n++ {
+		out = append(out, seatID(row, n))
+	}
+	return out
+}
+
+// onlyFree mengembalikan free yang hanya berisi seat yang disebutkan.
+func onlyFree(seatIDs ...[]uint) map[uint]bool {
+	free := make(map[uint]bool)
+	for _, group := range seatIDs {
+		for _, id := range group {
+			free[id] = true
+		}
+	}
+	return free
+}
+
+func TestSelectBestSeats(t *testing.T) {
+	tests := []struct {
+		name         string
+		seats        []seatModel.Seat
+		free         map[uint]bool
+		count        int
+		want         []uint
+		wantTogether bool
+		wantOK       bool
+	}{
+		{
+			name:         "centre of middle row",
+			seats:        hallSeats(10, 10, 10),
+			count:        2,
+			want:         rowSeats("B", 5, 6),
+			wantTogether: true,
+			wantOK:       true,
+		},
+		{
+			name:         "falls back to another row when middle row is full",
+			seats:        hallSeats(10, 10, 10),
+			free:         onlyFree(rowSeats("A", 1, 10), rowSeats("C", 1, 3)),
+			count:        4,
+			want:         rowSeats("A", 4, 7),
+			wantTogether: true,
+			wantOK:       true,
+		},
+		{
+			name:         "avoids leaving a single empty seat",
+			seats:        hallSeats(10),
+			free:         onlyFree(rowSeats("A", 3, 8)),
+			count:        4,
+			want:         rowSeats("A", 3, 6),
+			wantTogether: true,
+			wantOK:       true,
+		},
+		{
+			name:         "splits the party when no block is large enough",
+			seats:        hallSeats(10),
+			free:         onlyFree(rowSeats("A", 1, 2), rowSeats("A", 9, 10)),
+			count:        4,
+			want:         append(rowSeats("A", 1, 2), rowSeats("A", 9, 10)...),
+			wantTogether: false,
+			wantOK:       true,
+		},
+		{
+			name:   "not enough free seats",
+			seats:  hallSeats(10),
+			free:   onlyFree(rowSeats("A", 1, 3)),
+			count:  4,
+			wantOK: false,
+		},
+		{
+			name:   "empty party",
+			seats:  hallSeats(10),
+			count:  0,
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			free := tt.free
+			if free == nil {
+				free = freeExcept(tt.seats)
+			}
+
+			selected, together, ok := selectBestSeats(tt.seats, free, tt.count)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			want := slices.Clone(tt.want)
+			slices.Sort(want)
+			if got := ids(selected); !slices.Equal(got, want) {
+				t.Errorf("selected = %v, want %v", got, want)
+			}
+			if together != tt.wantTogether {
+				t.Errorf("together = %v, want %v", together, tt.wantTogether)
+			}
+		})
+	}
+}
+
+func TestBestWindow(t *testing.T) {
+	seats := hallSeats(10, 10, 10)
+	layouts := buildRowLayouts(seats)
+
+	tests := []struct {
+		name   string
+		free   map[uint]bool
+		size   int
+		want   []uint
+		wantOK bool
+	}{
+		{name: "single seat in the centre", free: freeExcept(seats), size: 1, want: rowSeats("B", 5, 5), wantOK: true},
+		{name: "whole row", free: freeExcept(seats), size: 10, want: rowSeats("B", 1, 10), wantOK: true},
+		{name: "block broken by a taken seat", free: onlyFree(rowSeats("A", 1, 3), rowSeats("A", 5, 7)), size: 3, want: rowSeats("A", 5, 7), wantOK: true},
+		{name: "no block large enough", free: onlyFree(rowSeats("A", 1, 2), rowSeats("B", 4, 5)), size: 3, wantOK: false},
+		{name: "larger than any row", free: freeExcept(seats), size: 11, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := bestWindow(layouts, tt.free, tt.size)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !slices.Equal(ids(got), tt.want) {
+				t.Errorf("window = %v, want %v", ids(got), tt.want)
+			}
+		})
+	}
+}
+
+// coupleRow membuat row A dengan seat standard 1-4 dan couple seat 5-6.
+func coupleRow() []seatModel.Seat {
+	seats := hallSeats(4)
+	return append(seats,
+		newSeat("A", 5, "couple", 2),
+		newSeat("A", 6, "couple", 2),
+	)
+}
+
+func TestCanSeat(t *testing.T) {
+	seats := coupleRow()
+	a := func(n int) uint { return seatID("A", n) }
+
+	tests := []struct {
+		name  string
+		free  map[uint]bool
+		count int
+		want  bool
+	}{
+		{name: "empty party", free: onlyFree(), count: 0, want: true},
+		{name: "standard and couple seat", free: onlyFree([]uint{a(1), a(5)}), count: 3, want: true},
+		{name: "two couple seats for four", free: onlyFree([]uint{a(5), a(6)}), count: 4, want: true},
+		{name: "couple seat cannot seat one", free: onlyFree([]uint{a(5), a(6)}), count: 1, want: false},
+		{name: "couple seats cannot seat an odd party", free: onlyFree([]uint{a(5), a(6)}), count: 3, want: false},
+		{name: "not enough capacity", free: onlyFree([]uint{a(1), a(5)}), count: 4, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canSeat(seats, tt.free, tt.count); got != tt.want {
+				t.Errorf("canSeat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestWindowCapacity(t *testing.T) {
+	layouts := buildRowLayouts(coupleRow())
+	a := func(n int) uint { return seatID("A", n) }
+
+	tests := []struct {
+		name   string
+		free   map[uint]bool
+		size   int
+		want   []uint
+		wantOK bool
+	}{
+		{name: "one couple seat for two", free: onlyFree([]uint{a(5), a(6)}), size: 2, want: []uint{a(5)}, wantOK: true},
+		{name: "couple seat is not used for one", free: onlyFree([]uint{a(5), a(6)}), size: 1, wantOK: false},
+		{name: "window must fill the couple seat exactly", free: onlyFree([]uint{a(4), a(5)}), size: 2, want: []uint{a(5)}, wantOK: true},
+		{name: "standard next to couple seat for three", free: onlyFree([]uint{a(4), a(5)}), size: 3, want: []uint{a(4), a(5)}, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := bestWindow(layouts, tt.free, tt.size)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !slices.Equal(ids(got), tt.want) {
+				t.Errorf("window = %v, want %v", ids(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectBestSeatsCapacity(t *testing.T) {
+	seats := coupleRow()
+	a := func(n int) uint { return seatID("A", n) }
+
+	// tiga orang: blok tiga tidak ada, jadi couple seat ditambah satu seat standard
+	selected, together, ok := selectBestSeats(seats, onlyFree([]uint{a(1), a(5), a(6)}), 3)
+	if !ok {
+		t.Fatal("ok = false, want true")
+	}
+	if got, want := ids(selected), []uint{a(1), a(5)}; !slices.Equal(got, want) {
+		t.Errorf("selected = %v, want %v", got, want)
+	}
+	if together {
+		t.Error("together = true, want false")
+	}
+
+	// satu orang tidak boleh mendapat couple seat
+	if _, _, ok := selectBestSeats(seats, onlyFree([]uint{a(5), a(6)}), 1); ok {
+		t.Error("one person was seated on a couple seat")
+	}
+}
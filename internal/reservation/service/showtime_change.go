@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+)
+
+// reasonRescheduleDeclined dipakai sebagai alasan pembatalan saat user menolak jadwal baru.
+const reasonRescheduleDeclined = "declined rescheduled showtime"
+
+// CancelShowtimeReservations membatalkan semua reservation pending dan confirmed
+// di showtime yang dibatalkan. Seat dilepas lewat transisi cancel, dan
+// reservation yang sudah dibayar di-refund dalam transaksi yang sama, sehingga
+// pemanggilan ulang setelah gagal di tengah jalan tidak meninggalkan reservation
+// cancelled tanpa refund.
+func (s *reservationService) CancelShowtimeReservations(ctx context.Context, actor *userModel.User, showtimeID uint, reason string) (int, int, error) {
+	var cancelled, refunded int
+	for _, status := range []string{model.StatusPending, model.StatusConfirmed} {
+		reservations, err := s.reservationRepo.GetAll(ctx, repository.ReservationFilter{ShowtimeID: showtimeID, Status: status})
+		if err != nil {
+			return cancelled, refunded, err
+		}
+
+		for _, r := range reservations {
+			res, err := s.cancelAndRefund(ctx, r.ID, auditEvent(actor, reason), func(res *model.Reservation) error {
+				markCancelled(res, actor, reason)
+				return nil
+			})
+			if errors.Is(err, model.ErrInvalidTransition) {
+				// status sudah berubah sejak diambil, misalnya expired oleh sweeper
+				continue
+			}
+			if err != nil {
+				return cancelled, refunded, err
+			}
+			cancelled++
+			if res.Status == model.StatusRefunded {
+				refunded++
+			}
+		}
+	}
+
+	utils.InfoLogger.Printf("Showtime %d cancelled: %d reservations cancelled, %d refunded", showtimeID, cancelled, refunded)
+	return cancelled, refunded, nil
+}
+
+// RescheduleShowtime menyimpan jadwal baru showtime sekaligus menandai
+// reservation aktifnya dalam satu transaksi, lalu memberi tahu pemiliknya. User
+// lalu menerima jadwal baru lewat AcceptReschedule atau membatalkan dengan
+// refund lewat DeclineReschedule.
+func (s *reservationService) RescheduleShowtime(ctx context.Context, actor *userModel.User, previous, updated *showtimeModel.Showtime) (int, error) {
+	event := auditEvent(actor, "showtime rescheduled")
+	event.OldValue = model.ScheduleSnapshot{StartTime: previous.StartTime, EndTime: previous.EndTime}.String()
+	event.NewValue = model.ScheduleSnapshot{StartTime: updated.StartTime, EndTime: updated.EndTime}.String()
+
+	reservations, err := s.reservationRepo.RescheduleShowtime(ctx, updated, previous.StartTime, event)
+	if err != nil {
+		return 0, err
+	}
+	for _, r := range reservations {
+		s.publishByID(r.ID, s.rescheduleListeners)
+	}
+
+	utils.InfoLogger.Printf("Showtime %d rescheduled: %d reservations notified", updated.ID, len(reservations))
+	return len(reservations), nil
+}
+
+// AcceptReschedule dipakai user untuk tetap memakai reservation di jadwal baru.
+func (s *reservationService) AcceptReschedule(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error) {
+	_, err := s.reservationRepo.AcceptReschedule(ctx, id, auditEvent(actor, ""), func(r *model.Reservation) error {
+		if err := checkOwner(actor, r); err != nil {
+			return err
+		}
+		return checkPendingReschedule(r)
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to accept reschedule (reservation: %d): %v", id, err)
+		return nil, err
+	}
+
+	res, err := s.reservationRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Reservation %d accepted rescheduled showtime", id)
+	return mapper.ToReservationResponse(res), nil
+}
+
+// DeclineReschedule membatalkan reservation karena user menolak jadwal baru.
+// Batas waktu pembatalan tidak berlaku, dan reservation yang sudah dibayar
+// di-refund dalam transaksi yang sama.
+func (s *reservationService) DeclineReschedule(ctx context.Context, actor *userModel.User, id uint) (*response.ReservationResponse, error) {
+	return s.cancelAndRefund(ctx, id, auditEvent(actor, reasonRescheduleDeclined), func(r *model.Reservation) error {
+		if err := checkOwner(actor, r); err != nil {
+			return err
+		}
+		if err := checkPendingReschedule(r); err != nil {
+			return err
+		}
+		r.RescheduleStatus = model.RescheduleDeclined
+		markCancelled(r, actor, reasonRescheduleDeclined)
+		return nil
+	})
+}
+
+func (s *reservationService) OnRescheduled(listener StatusChangedListener) {
+	s.rescheduleListeners = append(s.rescheduleListeners, listener)
+}
+
+func checkPendingReschedule(r *model.Reservation) error {
+	if r.RescheduleStatus != model.ReschedulePending {
+		return ErrNoPendingReschedule
+	}
+	if r.Status != model.StatusPending && r.Status != model.StatusConfirmed {
+		return ErrNoPendingReschedule
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	users "github.com/didanslmn/movie-reservation-system.git/internal/users/repository"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+)
+
+var (
+	ErrTransferNotAllowed   = errors.New("only confirmed reservations of upcoming showtimes can be transferred")
+	ErrTransferPending      = errors.New("reservation already has a pending transfer")
+	ErrRecipientNotFound    = errors.New("no registered user with this email")
+	ErrTransferToSelf       = errors.New("cannot transfer a reservation to yourself")
+	ErrSeatNotInReservation = errors.New("seat is not part of this reservation")
+	ErrTransferForbidden    = errors.New("transfer belongs to another user")
+)
+
+type TransferService interface {
+	RequestTransfer(ctx context.Context, actor *userModel.User, reservationID uint, req *request.TransferRequest) (*response.TransferResponse, error)
+	GetMyTransfers(ctx context.Context, actor *userModel.User) ([]response.TransferResponse, error)
+	AcceptTransfer(ctx context.Context, actor *userModel.User, id uint) (*response.TransferResponse, error)
+	DeclineTransfer(ctx context.Context, actor *userModel.User, id uint) (*response.TransferResponse, error)
+	CancelTransfer(ctx context.Context, actor *userModel.User, id uint) (*response.TransferResponse, error)
+	OnTransferRequested(listener TransferListener)
+}
+
+// TransferListener dipanggil (di goroutine terpisah) setelah transfer dibuat,
+// dengan data transfer lengkap, misalnya untuk memberi tahu penerima.
+type TransferListener func(ctx context.Context, t *model.ReservationTransfer)
+
+type transferService struct {
+	transferRepo    repository.TransferRepository
+	reservationRepo repository.ReservationRepository
+	userRepo        users.UserRepository
+	limitSvc        BookingLimitService
+
+	requestListeners []TransferListener
+}
+
+func NewTransferService(transferRepo repository.TransferRepository, reservationRepo repository.ReservationRepository, userRepo users.UserRepository, limitSvc BookingLimitService) TransferService {
+	return &transferService{
+		transferRepo:    transferRepo,
+		reservationRepo: reservationRepo,
+		userRepo:        userRepo,
+		limitSvc:        limitSvc,
+	}
+}
+
+// RequestTransfer membuat permintaan transfer ke user lain berdasarkan email.
+// SeatIDs kosong berarti seluruh reservation dipindahkan.
+func (s *transferService) RequestTransfer(ctx context.Context, actor *userModel.User, reservationID uint, req *request.TransferRequest) (*response.TransferResponse, error) {
+	reservation, err := s.reservationRepo.GetByID(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkOwner(actor, reservation); err != nil {
+		return nil, err
+	}
+	if err := checkTransferable(reservation); err != nil {
+		return nil, err
+	}
+
+	recipient, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(req.Email))
+	if err != nil {
+		return nil, err
+	}
+	// guest tidak bisa login untuk menerima transfer
+	if recipient == nil || recipient.Role == userModel.RoleGuest {
+		return nil, ErrRecipientNotFound
+	}
+	if recipient.ID == reservation.UserID {
+		return nil, ErrTransferToSelf
+	}
+
+	seatIDs := normalizeSeatIDs(req.SeatIDs)
+	for _, id := range seatIDs {
+		if !slices.Contains(reservationSeatIDs(reservation), id) {
+			return nil, ErrSeatNotInReservation
+		}
+	}
+	if len(seatIDs) == len(reservation.Seats) {
+		seatIDs = nil
+	}
+
+	pending, err := s.transferRepo.HasPending(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrTransferPending
+	}
+
+	transfer := &model.ReservationTransfer{
+		ReservationID: reservationID,
+		FromUserID:    reservation.UserID,
+		ToUserID:      recipient.ID,
+		Status:        model.TransferPending,
+	}
+	transfer.SetSeats(seatIDs)
+	if err := s.transferRepo.Create(ctx, transfer); err != nil {
+		return nil, err
+	}
+
+	created, err := s.transferRepo.GetByID(ctx, transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Transfer %d requested: reservation %d from user %d to user %d", created.ID, reservationID, created.FromUserID, created.ToUserID)
+	for _, listener := range s.requestListeners {
+		go listener(context.Background(), created)
+	}
+	return mapper.ToTransferResponse(created), nil
+}
+
+func (s *transferService) GetMyTransfers(ctx context.Context, actor *userModel.User) ([]response.TransferResponse, error) {
+	transfers, err := s.transferRepo.GetByUser(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToTransferResponseList(transfers), nil
+}
+
+// AcceptTransfer dipakai penerima. Reservation (atau seat yang dipindahkan)
+// menjadi milik penerima dan tiket lama pengirim dicabut.
+func (s *transferService) AcceptTransfer(ctx context.Context, actor *userModel.User, id uint) (*response.TransferResponse, error) {
+	_, err := s.transferRepo.Accept(ctx, id, auditEvent(actor, "transfer accepted"), func(t *model.ReservationTransfer, r *model.Reservation) error {
+		if t.ToUserID != actor.ID {
+			return ErrTransferForbidden
+		}
+		// reservation bisa berubah sejak transfer diminta
+		if r.UserID != t.FromUserID {
+			return ErrTransferNotAllowed
+		}
+		if err := checkTransferable(r); err != nil {
+			return err
+		}
+		for _, seatID := range t.Seats() {
+			if !slices.Contains(reservationSeatIDs(r), seatID) {
+				return ErrSeatNotInReservation
+			}
+		}
+		return nil
+	}, func(seats []seatModel.Seat) (*repository.BookingGuard, error) {
+		// seat yang diterima dihitung ke batas booking penerima
+		return bookingGuard(ctx, s.limitSvc, actor.ID, seatModel.TotalCapacity(seats), false)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transfer, err := s.transferRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Transfer %d accepted by user %d", id, actor.ID)
+
+	res := mapper.ToTransferResponse(transfer)
+	reservationID := transfer.ReservationID
+	if transfer.NewReservationID != nil {
+		reservationID = *transfer.NewReservationID
+	}
+	reservation, err := s.reservationRepo.GetByID(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	res.Reservation = mapper.ToReservationResponse(reservation)
+	return res, nil
+}
+
+func (s *transferService) DeclineTransfer(ctx context.Context, actor *userModel.User, id uint) (*response.TransferResponse, error) {
+	return s.close(ctx, actor, id, model.TransferDeclined, func(t *model.ReservationTransfer) bool {
+		return t.ToUserID == actor.ID
+	})
+}
+
+// CancelTransfer dipakai pengirim (atau admin) untuk menarik transfer yang belum diterima.
+func (s *transferService) CancelTransfer(ctx context.Context, actor *userModel.User, id uint) (*response.TransferResponse, error) {
+	return s.close(ctx, actor, id, model.TransferCancelled, func(t *model.ReservationTransfer) bool {
+		return t.FromUserID == actor.ID || actor.Role == userModel.RoleAdmin
+	})
+}
+
+func (s *transferService) close(ctx context.Context, actor *userModel.User, id uint, status string, allowed func(t *model.ReservationTransfer) bool) (*response.TransferResponse, error) {
+	transfer, err := s.transferRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed(transfer) {
+		return nil, ErrTransferForbidden
+	}
+
+	ok, err := s.transferRepo.UpdateStatus(ctx, id, status, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, repository.ErrTransferNotPending
+	}
+
+	if transfer, err = s.transferRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Transfer %d %s by user %d", id, status, actor.ID)
+	return mapper.ToTransferResponse(transfer), nil
+}
+
+func (s *transferService) OnTransferRequested(listener TransferListener) {
+	s.requestListeners = append(s.requestListeners, listener)
+}
+
+func checkTransferable(r *model.Reservation) error {
+	if r.Status != model.StatusConfirmed || !r.Showtime.StartTime.After(time.Now()) {
+		return ErrTransferNotAllowed
+	}
+	return nil
+}
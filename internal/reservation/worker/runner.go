@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+)
+
+// Job memproses satu batch dan mengembalikan jumlah data yang diproses.
+type Job func(ctx context.Context) (int, error)
+
+// Runner menjalankan job secara berkala sampai ctx dibatalkan. Dalam satu
+// putaran job diulang selama masih memproses batch penuh.
+type Runner struct {
+	name      string
+	interval  time.Duration
+	batchSize int
+	job       Job
+}
+
+func NewRunner(name string, interval time.Duration, batchSize int, job Job) *Runner {
+	return &Runner{name: name, interval: interval, batchSize: batchSize, job: job}
+}
+
+// Start menjalankan runner di goroutine terpisah sampai ctx dibatalkan.
+func (w *Runner) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		utils.InfoLogger.Printf("%s started (interval: %s)", w.name, w.interval)
+		for {
+			select {
+			case <-ctx.Done():
+				utils.InfoLogger.Printf("%s stopped", w.name)
+				return
+			case <-ticker.C:
+				w.run(ctx)
+			}
+		}
+	}()
+}
+
+func (w *Runner) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := w.job(ctx)
+		if err != nil {
+			utils.ErrorLogger.Printf("%s failed: %v", w.name, err)
+			return
+		}
+		if n > 0 {
+			utils.InfoLogger.Printf("%s processed %d reservations", w.name, n)
+		}
+		if n < w.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package worker
+
+import (
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+)
+
+// NewExpirySweeper secara berkala meng-expire reservation pending yang
+// hold-nya sudah habis sehingga seat bisa dijual lagi.
+func NewExpirySweeper(svc service.ReservationService, interval time.Duration) *Runner {
+	return NewRunner("Reservation expiry sweeper", interval, service.ExpireBatchSize, svc.ExpirePendingReservations)
+}
+
+// NewCompletionSweeper secara berkala menutup reservation yang showtime-nya
+// sudah selesai menjadi completed atau no_show.
+func NewCompletionSweeper(svc service.ReservationService, interval time.Duration) *Runner {
+	return NewRunner("Reservation completion sweeper", interval, service.ExpireBatchSize, svc.CompleteFinishedReservations)
+}
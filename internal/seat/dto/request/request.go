@@ -4,9 +4,33 @@ type CreateSeatRequest struct {
 	CinemaHallID uint   `json:"cinema_hall_id" binding:"required"`
 	SeatNumber   string `json:"seat_number" binding:"required"`
 	Row          string `json:"row" binding:"required"`
+	// Category kosong berarti kategori standard
+	Category string `json:"category" binding:"omitempty,max=30"`
 }
+
+// UpdateSeatRequest hanya mengubah field yang diisi.
 type UpdateSeatRequest struct {
 	SeatNumber string `json:"seat_number"`
 	Row        string `json:"row"`
 	Status     string `json:"status" binding:"omitempty,oneof=available reserved maintenance broken"`
+	// Category harus sudah didefinisikan admin; kosong berarti tidak diubah
+	Category string `json:"category" binding:"omitempty,max=30"`
+}
+
+type CreateSeatCategoryRequest struct {
+	Name        string `json:"name" binding:"required,max=30,lowercase"`
+	Description string `json:"description" binding:"max=255"`
+	// PricePercent dan PriceAmount adalah modifier harga kategori terhadap base price showtime
+	PricePercent int   `json:"price_percent" binding:"min=-100,max=1000"`
+	PriceAmount  int64 `json:"price_amount"`
+	Capacity     int   `json:"capacity" binding:"required,min=1,max=10"`
+}
+
+// UpdateSeatCategoryRequest adalah perubahan sebagian; field yang tidak
+// dikirim tidak diubah.
+type UpdateSeatCategoryRequest struct {
+	Description  *string `json:"description" binding:"omitempty,max=255"`
+	PricePercent *int    `json:"price_percent" binding:"omitempty,min=-100,max=1000"`
+	PriceAmount  *int64  `json:"price_amount"`
+	Capacity     *int    `json:"capacity" binding:"omitempty,min=1,max=10"`
 }
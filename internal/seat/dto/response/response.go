@@ -1,9 +1,23 @@
 package response
 
+import "time"
+
 type SeatResponse struct {
 	ID           uint   `json:"id"`
 	SeatNumber   string `json:"seat_number"`
 	Row          string `json:"row"`
 	Status       string `json:"status"`
+	Category     string `json:"category"`
+	Capacity     int    `json:"capacity"`
 	CinemaHallID uint   `json:"cinema_hall_id"`
 }
+
+type SeatCategoryResponse struct {
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	PricePercent int       `json:"price_percent"`
+	PriceAmount  int64     `json:"price_amount"`
+	Capacity     int       `json:"capacity"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
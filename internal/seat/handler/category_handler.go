@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/repository"
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/service"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type SeatCategoryHandler struct {
+	service service.SeatCategoryService
+}
+
+func NewSeatCategoryHandler(service service.SeatCategoryService) *SeatCategoryHandler {
+	return &SeatCategoryHandler{service: service}
+}
+
+func (h *SeatCategoryHandler) CreateCategory(c *gin.Context) {
+	var req request.CreateSeatCategoryRequest
+	if ok := utils.BindAndValidate(c, &req); !ok {
+		return
+	}
+
+	res, err := h.service.CreateCategory(c.Request.Context(), req)
+	if err != nil {
+		respondCategoryError(c, "Failed to create seat category", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Seat category created successfully", res)
+}
+
+func (h *SeatCategoryHandler) GetAllCategories(c *gin.Context) {
+	res, err := h.service.GetAllCategories(c.Request.Context())
+	if err != nil {
+		respondCategoryError(c, "Failed to fetch seat categories", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Seat categories fetched successfully", res)
+}
+
+func (h *SeatCategoryHandler) UpdateCategory(c *gin.Context) {
+	var req request.UpdateSeatCategoryRequest
+	if ok := utils.BindAndValidate(c, &req); !ok {
+		return
+	}
+
+	res, err := h.service.UpdateCategory(c.Request.Context(), c.Param("name"), req)
+	if err != nil {
+		respondCategoryError(c, "Failed to update seat category", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Seat category updated successfully", res)
+}
+
+func (h *SeatCategoryHandler) DeleteCategory(c *gin.Context) {
+	if err := h.service.DeleteCategory(c.Request.Context(), c.Param("name")); err != nil {
+		respondCategoryError(c, "Failed to delete seat category", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Seat category deleted successfully", nil)
+}
+
+func respondCategoryError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrCategoryNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, "Seat category not found", err)
+	case errors.Is(err, service.ErrCategoryExists),
+		errors.Is(err, repository.ErrCategoryInUse),
+		errors.Is(err, service.ErrDefaultCategory):
+		utils.RespondWithError(c, http.StatusConflict, err.Error(), err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, msg, err)
+	}
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -25,6 +26,10 @@ func (h *SeatHandler) CreateSeat(c *gin.Context) {
 		return
 	}
 	seat, err := h.service.CreateSeat(c.Request.Context(), req)
+	if errors.Is(err, service.ErrUnknownCategory) {
+		utils.RespondWithError(c, http.StatusBadRequest, "Unknown seat category", err)
+		return
+	}
 	if err != nil {
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to create seat", err)
 		return
@@ -79,6 +84,10 @@ func (h *SeatHandler) UpdateSeat(c *gin.Context) {
 	}
 
 	seat, err := h.service.UpdateSeat(c.Request.Context(), uint(id), req)
+	if errors.Is(err, service.ErrUnknownCategory) {
+		utils.RespondWithError(c, http.StatusBadRequest, "Unknown seat category", err)
+		return
+	}
 	if err != nil {
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to update seat", err)
 		return
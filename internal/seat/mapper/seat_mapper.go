@@ -12,6 +12,8 @@ func ToSeatResponse(s *model.Seat) *response.SeatResponse {
 		SeatNumber:   s.SeatNumber,
 		Row:          s.Row,
 		Status:       s.Status,
+		Category:     s.Category,
+		Capacity:     s.Capacity(),
 		CinemaHallID: s.CinemaHallID,
 	}
 }
@@ -29,5 +31,52 @@ func ToSeatModel(req *request.CreateSeatRequest) *model.Seat {
 		SeatNumber:   req.SeatNumber,
 		Row:          req.Row,
 		Status:       "available", // default
+		Category:     req.Category,
+	}
+}
+
+func ToSeatCategoryResponse(c *model.SeatCategory) *response.SeatCategoryResponse {
+	return &response.SeatCategoryResponse{
+		Name:         c.Name,
+		Description:  c.Description,
+		PricePercent: c.PricePercent,
+		PriceAmount:  c.PriceAmount,
+		Capacity:     c.Capacity,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}
+}
+
+func ToSeatCategoryResponseList(categories []model.SeatCategory) []response.SeatCategoryResponse {
+	responses := make([]response.SeatCategoryResponse, 0, len(categories))
+	for i := range categories {
+		responses = append(responses, *ToSeatCategoryResponse(&categories[i]))
+	}
+	return responses
+}
+
+func ToSeatCategory(req request.CreateSeatCategoryRequest) *model.SeatCategory {
+	return &model.SeatCategory{
+		Name:         req.Name,
+		Description:  req.Description,
+		PricePercent: req.PricePercent,
+		PriceAmount:  req.PriceAmount,
+		Capacity:     req.Capacity,
+	}
+}
+
+// ApplySeatCategoryUpdate mengisi field kategori yang dikirim admin.
+func ApplySeatCategoryUpdate(c *model.SeatCategory, req request.UpdateSeatCategoryRequest) {
+	if req.Description != nil {
+		c.Description = *req.Description
+	}
+	if req.PricePercent != nil {
+		c.PricePercent = *req.PricePercent
+	}
+	if req.PriceAmount != nil {
+		c.PriceAmount = *req.PriceAmount
+	}
+	if req.Capacity != nil {
+		c.Capacity = *req.Capacity
 	}
 }
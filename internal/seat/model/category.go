@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// CategoryStandard adalah kategori default untuk seat baru.
+const CategoryStandard = "standard"
+
+// SeatCategory adalah definisi kategori seat yang dikelola admin. Capacity
+// adalah jumlah orang per seat (misalnya couple seat = 2), dipakai untuk batas
+// booking dan ketersediaan. Modifier harga diterapkan sebelum price rule.
+type SeatCategory struct {
+	Name         string `gorm:"primaryKey;type:varchar(30)"`
+	Description  string `gorm:"type:varchar(255)"`
+	PricePercent int    `gorm:"not null"`
+	PriceAmount  int64  `gorm:"not null"`
+	Capacity     int    `gorm:"not null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Capacity mengembalikan jumlah orang untuk seat; seat yang definisi
+// kategorinya tidak dimuat dihitung satu orang.
+func (s Seat) Capacity() int {
+	if s.CategoryInfo != nil && s.CategoryInfo.Capacity > 0 {
+		return s.CategoryInfo.Capacity
+	}
+	return 1
+}
+
+// TotalCapacity menjumlahkan kapasitas seat.
+func TotalCapacity(seats []Seat) int {
+	total := 0
+	for _, seat := range seats {
+		total += seat.Capacity()
+	}
+	return total
+}
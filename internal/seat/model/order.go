@@ -0,0 +1,39 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+)
+
+// SeatNumberValue mengambil angka di akhir SeatNumber, misalnya "A12" -> 12.
+func SeatNumberValue(seatNumber string) (int, bool) {
+	end := len(seatNumber)
+	start := end
+	for start > 0 && seatNumber[start-1] >= '0' && seatNumber[start-1] <= '9' {
+		start--
+	}
+	if start == end {
+		return 0, false
+	}
+	n, err := strconv.Atoi(seatNumber[start:end])
+	return n, err == nil
+}
+
+// CompareRow mengurutkan row secara natural: "B" < "AA", "2" < "10".
+func CompareRow(a, b string) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	return strings.Compare(a, b)
+}
+
+// CompareSeatNumber mengurutkan seat dalam satu row berdasarkan angkanya,
+// sehingga "A2" berada sebelum "A10".
+func CompareSeatNumber(a, b string) int {
+	na, okA := SeatNumberValue(a)
+	nb, okB := SeatNumberValue(b)
+	if okA && okB && na != nb {
+		return na - nb
+	}
+	return strings.Compare(a, b)
+}
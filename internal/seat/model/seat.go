@@ -8,4 +8,7 @@ type Seat struct {
 	SeatNumber   string `gorm:"not null"`
 	Row          string `gorm:"not null"`
 	Status       string `gorm:"default:'available'"`
+	// Category mengacu ke SeatCategory, dipakai pricing dan perhitungan kapasitas
+	Category     string        `gorm:"type:varchar(30);not null;default:'standard'"`
+	CategoryInfo *SeatCategory `gorm:"foreignKey:Category;references:Name;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 }
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+const (
+	ShowtimeSeatAvailable = "available"
+	ShowtimeSeatHeld      = "held"
+	ShowtimeSeatBooked    = "booked"
+)
+
+// ShowtimeSeat adalah inventori seat untuk satu showtime, sehingga seat fisik
+// yang sama bisa dijual sekali per showtime.
+type ShowtimeSeat struct {
+	ID            uint   `gorm:"primaryKey"`
+	ShowtimeID    uint   `gorm:"not null;uniqueIndex:idx_showtime_seats_showtime_seat"`
+	SeatID        uint   `gorm:"not null;uniqueIndex:idx_showtime_seats_showtime_seat"`
+	Seat          Seat   `gorm:"foreignKey:SeatID"`
+	Status        string `gorm:"type:varchar(20);not null;default:'available'"`
+	ReservationID *uint  `gorm:"index"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
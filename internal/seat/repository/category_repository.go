@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+type SeatCategoryRepository interface {
+	Create(ctx context.Context, category *model.SeatCategory) error
+	GetByName(ctx context.Context, name string) (*model.SeatCategory, error)
+	GetAll(ctx context.Context) ([]model.SeatCategory, error)
+	Update(ctx context.Context, category *model.SeatCategory) error
+	Delete(ctx context.Context, name string) error
+}
+
+type seatCategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewSeatCategoryRepository(db *gorm.DB) SeatCategoryRepository {
+	return &seatCategoryRepository{db: db}
+}
+
+var (
+	ErrCategoryNotFound = errors.New("seat category not found")
+	ErrCategoryInUse    = errors.New("seat category is still used by seats")
+)
+
+// foreignKeyViolation adalah kode error PostgreSQL untuk pelanggaran foreign key
+const foreignKeyViolation = "23503"
+
+func (r *seatCategoryRepository) Create(ctx context.Context, category *model.SeatCategory) error {
+	if err := r.db.WithContext(ctx).Create(category).Error; err != nil {
+		utils.ErrorLogger.Printf("failed to create seat category %q: %v", category.Name, err)
+		return fmt.Errorf("failed to create seat category: %w", err)
+	}
+	return nil
+}
+
+func (r *seatCategoryRepository) GetByName(ctx context.Context, name string) (*model.SeatCategory, error) {
+	var category model.SeatCategory
+	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCategoryNotFound
+		}
+		utils.ErrorLogger.Printf("failed to get seat category %q: %v", name, err)
+		return nil, fmt.Errorf("failed to get seat category: %w", err)
+	}
+	return &category, nil
+}
+
+func (r *seatCategoryRepository) GetAll(ctx context.Context) ([]model.SeatCategory, error) {
+	var categories []model.SeatCategory
+	if err := r.db.WithContext(ctx).Order("name").Find(&categories).Error; err != nil {
+		utils.ErrorLogger.Printf("failed to get seat categories: %v", err)
+		return nil, fmt.Errorf("failed to get seat categories: %w", err)
+	}
+	return categories, nil
+}
+
+func (r *seatCategoryRepository) Update(ctx context.Context, category *model.SeatCategory) error {
+	if err := r.db.WithContext(ctx).Save(category).Error; err != nil {
+		utils.ErrorLogger.Printf("failed to update seat category %q: %v", category.Name, err)
+		return fmt.Errorf("failed to update seat category: %w", err)
+	}
+	return nil
+}
+
+// Delete menghapus kategori. Kategori yang masih dipakai seat, termasuk seat
+// yang sudah di-soft delete, ditolak oleh foreign key seats.category sehingga
+// tidak ada jeda antara pengecekan dan penghapusan.
+func (r *seatCategoryRepository) Delete(ctx context.Context, name string) error {
+	result := r.db.WithContext(ctx).Where("name = ?", name).Delete(&model.SeatCategory{})
+	var pgErr *pgconn.PgError
+	if errors.As(result.Error, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return ErrCategoryInUse
+	}
+	if result.Error != nil {
+		utils.ErrorLogger.Printf("failed to delete seat category %q: %v", name, result.Error)
+		return fmt.Errorf("failed to delete seat category: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCategoryNotFound
+	}
+	return nil
+}
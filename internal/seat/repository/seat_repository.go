@@ -7,6 +7,7 @@ import (
 	"github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
 	"github.com/didanslmn/movie-reservation-system.git/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SeatRepository interface {
@@ -17,8 +18,8 @@ type SeatRepository interface {
 	Delete(ctx context.Context, id uint) error
 	// ExistsByID(ctx context.Context, id uint) (bool, error)
 	GetByIDs(ctx context.Context, ids []uint) ([]model.Seat, error)
-	IsSeatAvailable(seatID uint, showtimeID uint) (bool, error)
-	UpdateStatus(ctx context.Context, seatID uint, status string) error
+	GetAvailableSeats(ctx context.Context, showtimeID uint) ([]model.Seat, error)
+	GetShowtimeSeats(ctx context.Context, showtimeID uint) ([]model.ShowtimeSeat, error)
 }
 
 type seatRepository struct {
@@ -30,16 +31,27 @@ func NewSeatRepository(db *gorm.DB) SeatRepository {
 }
 
 func (r *seatRepository) Create(ctx context.Context, seat *model.Seat) error {
-	if err := r.db.WithContext(ctx).Create(seat).Error; err != nil {
-		utils.ErrorLogger.Printf("failed to create seat: %v", err)
-		return fmt.Errorf("failed to create seat: %w", err)
-	}
-	return nil
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(seat).Error; err != nil {
+			utils.ErrorLogger.Printf("failed to create seat: %v", err)
+			return fmt.Errorf("failed to create seat: %w", err)
+		}
+		// seat baru ikut dijual untuk showtime di hall yang sama yang belum mulai
+		err := tx.Exec(`INSERT INTO showtime_seats (showtime_id, seat_id, status, created_at, updated_at)
+			SELECT id, ?, ?, NOW(), NOW() FROM showtimes
+			WHERE cinema_hall_id = ? AND start_time > NOW() AND deleted_at IS NULL`,
+			seat.ID, model.ShowtimeSeatAvailable, seat.CinemaHallID).Error
+		if err != nil {
+			utils.ErrorLogger.Printf("failed to add seat %d to upcoming showtimes: %v", seat.ID, err)
+			return fmt.Errorf("failed to add seat to showtimes: %w", err)
+		}
+		return nil
+	})
 }
 
 func (r *seatRepository) GetByID(ctx context.Context, id uint) (*model.Seat, error) {
 	var seat model.Seat
-	if err := r.db.WithContext(ctx).First(&seat, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("CategoryInfo").First(&seat, id).Error; err != nil {
 		utils.ErrorLogger.Printf("failed to get seat by id %d: %v", id, err)
 		return nil, fmt.Errorf("failed to get seat by ID %w", err)
 	}
@@ -48,7 +60,7 @@ func (r *seatRepository) GetByID(ctx context.Context, id uint) (*model.Seat, err
 
 func (r *seatRepository) GetByHallID(ctx context.Context, hallID uint) ([]model.Seat, error) {
 	var seats []model.Seat
-	if err := r.db.WithContext(ctx).Where("cinema_hall_id = ?", hallID).Find(&seats).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("CategoryInfo").Where("cinema_hall_id = ?", hallID).Find(&seats).Error; err != nil {
 		utils.ErrorLogger.Printf("failed to get seats by hall id %d: %v", hallID, err)
 		return nil, fmt.Errorf("failed get by hall: %w", err)
 	}
@@ -56,7 +68,8 @@ func (r *seatRepository) GetByHallID(ctx context.Context, hallID uint) ([]model.
 }
 
 func (r *seatRepository) Update(ctx context.Context, seat *model.Seat) error {
-	if err := r.db.WithContext(ctx).Save(seat).Error; err != nil {
+	// definisi kategori tidak ikut disimpan, seat hanya menyimpan nama kategorinya
+	if err := r.db.WithContext(ctx).Omit(clause.Associations).Save(seat).Error; err != nil {
 		utils.ErrorLogger.Printf("failed to update seat id %d: %v", seat.ID, err)
 		return err
 	}
@@ -81,26 +94,40 @@ func (r *seatRepository) Delete(ctx context.Context, id uint) error {
 //	}
 func (r *seatRepository) GetByIDs(ctx context.Context, ids []uint) ([]model.Seat, error) {
 	var seats []model.Seat
-	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&seats).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("CategoryInfo").Where("id IN ?", ids).Find(&seats).Error; err != nil {
 		return nil, err
 	}
 	return seats, nil
 }
 
-func (r *seatRepository) IsSeatAvailable(seatID uint, showtimeID uint) (bool, error) {
-	var count int64
-	err := r.db.Table("reservations").
-		Where("seat_id = ? AND showtime_id = ? AND is_active = TRUE", seatID, showtimeID).
-		Count(&count).Error
-
+// GetAvailableSeats mengembalikan seat yang masih bisa dijual untuk showtime,
+// diurutkan per row lalu nomor seat.
+func (r *seatRepository) GetAvailableSeats(ctx context.Context, showtimeID uint) ([]model.Seat, error) {
+	var seats []model.Seat
+	err := r.db.WithContext(ctx).
+		Preload("CategoryInfo").
+		Joins("JOIN showtime_seats ON showtime_seats.seat_id = seats.id").
+		Where("showtime_seats.showtime_id = ? AND showtime_seats.status = ? AND seats.status = ?", showtimeID, model.ShowtimeSeatAvailable, "available").
+		Order("seats.row, LENGTH(seats.seat_number), seats.seat_number").
+		Find(&seats).Error
 	if err != nil {
-		return false, err
+		utils.ErrorLogger.Printf("failed to get available seats for showtime %d: %v", showtimeID, err)
+		return nil, fmt.Errorf("failed to get available seats: %w", err)
 	}
-	return count == 0, nil
+	return seats, nil
 }
-func (r *seatRepository) UpdateStatus(ctx context.Context, seatID uint, status string) error {
-	return r.db.WithContext(ctx).
-		Model(&model.Seat{}).
-		Where("id = ?", seatID).
-		Update("status", status).Error
+
+// GetShowtimeSeats mengembalikan inventori seat showtime beserta data seat fisiknya.
+func (r *seatRepository) GetShowtimeSeats(ctx context.Context, showtimeID uint) ([]model.ShowtimeSeat, error) {
+	var showtimeSeats []model.ShowtimeSeat
+	err := r.db.WithContext(ctx).
+		Joins("JOIN seats ON seats.id = showtime_seats.seat_id AND seats.deleted_at IS NULL").
+		Preload("Seat.CategoryInfo").
+		Where("showtime_seats.showtime_id = ?", showtimeID).
+		Find(&showtimeSeats).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("failed to get seats for showtime %d: %v", showtimeID, err)
+		return nil, fmt.Errorf("failed to get showtime seats: %w", err)
+	}
+	return showtimeSeats, nil
 }
@@ -7,7 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func SeatRouts(rg *gin.RouterGroup, h *handler.SeatHandler, jwtSecret string) {
+func SeatRouts(rg *gin.RouterGroup, h *handler.SeatHandler, categoryHdl *handler.SeatCategoryHandler, jwtSecret string) {
 	seat := rg.Group("/seat")
 	adminRouts := seat.Group("/")
 	adminRouts.Use(middleware.JWTAuthMiddleware(jwtSecret))
@@ -18,5 +18,10 @@ func SeatRouts(rg *gin.RouterGroup, h *handler.SeatHandler, jwtSecret string) {
 		adminRouts.GET("/cinemahall/:hall_id", h.GetSeatsByHallID)
 		adminRouts.PUT("/:id", h.UpdateSeat)
 		adminRouts.DELETE("/:id", h.DeleteSeat)
+
+		adminRouts.GET("/categories", categoryHdl.GetAllCategories)
+		adminRouts.POST("/categories", categoryHdl.CreateCategory)
+		adminRouts.PUT("/categories/:name", categoryHdl.UpdateCategory)
+		adminRouts.DELETE("/categories/:name", categoryHdl.DeleteCategory)
 	}
 }
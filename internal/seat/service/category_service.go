@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/repository"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+)
+
+var (
+	ErrCategoryExists  = errors.New("seat category already exists")
+	ErrDefaultCategory = errors.New("default seat category cannot be deleted")
+)
+
+type SeatCategoryService interface {
+	CreateCategory(ctx context.Context, req request.CreateSeatCategoryRequest) (*response.SeatCategoryResponse, error)
+	GetAllCategories(ctx context.Context) ([]response.SeatCategoryResponse, error)
+	UpdateCategory(ctx context.Context, name string, req request.UpdateSeatCategoryRequest) (*response.SeatCategoryResponse, error)
+	DeleteCategory(ctx context.Context, name string) error
+}
+
+type seatCategoryService struct {
+	repo repository.SeatCategoryRepository
+}
+
+func NewSeatCategoryService(repo repository.SeatCategoryRepository) SeatCategoryService {
+	return &seatCategoryService{repo: repo}
+}
+
+func (s *seatCategoryService) CreateCategory(ctx context.Context, req request.CreateSeatCategoryRequest) (*response.SeatCategoryResponse, error) {
+	if _, err := s.repo.GetByName(ctx, req.Name); err == nil {
+		return nil, ErrCategoryExists
+	} else if !errors.Is(err, repository.ErrCategoryNotFound) {
+		return nil, err
+	}
+
+	category := mapper.ToSeatCategory(req)
+	if err := s.repo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Seat category created: %s", category.Name)
+	return mapper.ToSeatCategoryResponse(category), nil
+}
+
+func (s *seatCategoryService) GetAllCategories(ctx context.Context) ([]response.SeatCategoryResponse, error) {
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToSeatCategoryResponseList(categories), nil
+}
+
+// UpdateCategory mengubah field kategori yang dikirim saja. Perubahan harga
+// hanya berlaku untuk reservation baru karena harga reservation disimpan.
+func (s *seatCategoryService) UpdateCategory(ctx context.Context, name string, req request.UpdateSeatCategoryRequest) (*response.SeatCategoryResponse, error) {
+	category, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	mapper.ApplySeatCategoryUpdate(category, req)
+	if err := s.repo.Update(ctx, category); err != nil {
+		return nil, err
+	}
+	utils.InfoLogger.Printf("Seat category updated: %s", category.Name)
+	return mapper.ToSeatCategoryResponse(category), nil
+}
+
+func (s *seatCategoryService) DeleteCategory(ctx context.Context, name string) error {
+	if name == model.CategoryStandard {
+		return ErrDefaultCategory
+	}
+	if err := s.repo.Delete(ctx, name); err != nil {
+		return err
+	}
+	utils.InfoLogger.Printf("Seat category deleted: %s", name)
+	return nil
+}
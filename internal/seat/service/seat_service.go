@@ -2,11 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/didanslmn/movie-reservation-system.git/internal/seat/dto/request"
 	"github.com/didanslmn/movie-reservation-system.git/internal/seat/dto/response"
 	"github.com/didanslmn/movie-reservation-system.git/internal/seat/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
 	"github.com/didanslmn/movie-reservation-system.git/internal/seat/repository"
 	"github.com/didanslmn/movie-reservation-system.git/utils"
 )
@@ -19,16 +21,28 @@ type SeatService interface {
 	DeleteSeat(ctx context.Context, id uint) error
 }
 
+// ErrUnknownCategory dikembalikan saat seat memakai kategori yang belum didefinisikan admin
+var ErrUnknownCategory = errors.New("unknown seat category")
+
 type seatService struct {
-	repo repository.SeatRepository
+	repo         repository.SeatRepository
+	categoryRepo repository.SeatCategoryRepository
 }
 
-func NewSeatService(repo repository.SeatRepository) SeatService {
-	return &seatService{repo: repo}
+func NewSeatService(repo repository.SeatRepository, categoryRepo repository.SeatCategoryRepository) SeatService {
+	return &seatService{repo: repo, categoryRepo: categoryRepo}
 }
 
 func (s *seatService) CreateSeat(ctx context.Context, req request.CreateSeatRequest) (*response.SeatResponse, error) {
 	seat := mapper.ToSeatModel(&req)
+	if seat.Category == "" {
+		seat.Category = model.CategoryStandard
+	}
+	category, err := s.getCategory(ctx, seat.Category)
+	if err != nil {
+		return nil, err
+	}
+	seat.CategoryInfo = category
 
 	if err := s.repo.Create(ctx, seat); err != nil {
 		utils.ErrorLogger.Printf("Error Creating create seat: %v", err)
@@ -65,9 +79,24 @@ func (s *seatService) UpdateSeat(ctx context.Context, id uint, req request.Updat
 		return nil, fmt.Errorf("failed to update seat: %w", err)
 	}
 
-	seat.SeatNumber = req.SeatNumber
-	seat.Row = req.Row
-	seat.Status = req.Status
+	// field kosong berarti tidak diubah
+	if req.SeatNumber != "" {
+		seat.SeatNumber = req.SeatNumber
+	}
+	if req.Row != "" {
+		seat.Row = req.Row
+	}
+	if req.Status != "" {
+		seat.Status = req.Status
+	}
+	if req.Category != "" {
+		category, err := s.getCategory(ctx, req.Category)
+		if err != nil {
+			return nil, err
+		}
+		seat.Category = category.Name
+		seat.CategoryInfo = category
+	}
 
 	if err := s.repo.Update(ctx, seat); err != nil {
 		utils.ErrorLogger.Printf("Error to updating seat id %d: %v", id, err)
@@ -77,6 +106,14 @@ func (s *seatService) UpdateSeat(ctx context.Context, id uint, req request.Updat
 	return mapper.ToSeatResponse(seat), nil
 }
 
+func (s *seatService) getCategory(ctx context.Context, name string) (*model.SeatCategory, error) {
+	category, err := s.categoryRepo.GetByName(ctx, name)
+	if errors.Is(err, repository.ErrCategoryNotFound) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCategory, name)
+	}
+	return category, err
+}
+
 func (s *seatService) DeleteSeat(ctx context.Context, id uint) error {
 	if err := s.repo.Delete(ctx, id); err != nil {
 		utils.ErrorLogger.Printf("Error to delete seat id %d: %v", id, err)
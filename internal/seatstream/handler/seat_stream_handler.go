@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/seatstream/service"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// heartbeatInterval menjaga koneksi tetap hidup di balik proxy yang memutus koneksi idle
+const heartbeatInterval = 25 * time.Second
+
+type SeatStreamHandler struct {
+	service service.SeatStreamService
+}
+
+func NewSeatStreamHandler(service service.SeatStreamService) *SeatStreamHandler {
+	return &SeatStreamHandler{service: service}
+}
+
+// Stream mengirim perubahan seat showtime sebagai Server-Sent Events sampai
+// client menutup koneksi.
+func (h *SeatStreamHandler) Stream(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid showtime ID", err)
+		return
+	}
+
+	events, unsubscribe, err := h.service.Subscribe(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Showtime not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch showtime", err)
+		return
+	}
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.SSEvent("ready", gin.H{"showtime_id": id})
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(e.Type, e)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("ping", time.Now().Unix())
+			return true
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Nama event SSE untuk perubahan seat.
+const (
+	EventHeld     = "held"
+	EventBooked   = "booked"
+	EventReleased = "released"
+)
+
+// Event dikirim ke client yang sedang membuka seat picker satu showtime.
+type Event struct {
+	Type       string    `json:"type"`
+	ShowtimeID uint      `json:"showtime_id"`
+	SeatIDs    []uint    `json:"seat_ids"`
+	At         time.Time `json:"at"`
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	"github.com/didanslmn/movie-reservation-system.git/internal/seatstream/handler"
+	"github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/gin-gonic/gin"
+)
+
+func SeatStreamRoutes(rg *gin.RouterGroup, h *handler.SeatStreamHandler, jwtSecret string) {
+	showtimes := rg.Group("/showtimes")
+	showtimes.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	showtimes.Use(middleware.RoleBasedAccess(model.RoleUser, model.RoleAdmin))
+	{
+		showtimes.GET("/:id/seats/stream", h.Stream)
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	reservationService "github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/seatstream/model"
+	showtimeRepository "github.com/didanslmn/movie-reservation-system.git/internal/showtime/repository"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+)
+
+// subscriberBuffer adalah jumlah event yang boleh tertunda per client
+const subscriberBuffer = 32
+
+// SeatStreamService menyebarkan perubahan seat ke semua client per showtime di
+// dalam satu proses. Client yang terlalu lambat diputus agar tidak menahan
+// publisher; client tersebut cukup reconnect dan memuat ulang seat map.
+type SeatStreamService interface {
+	// Subscribe mendaftarkan client untuk showtime. Fungsi yang dikembalikan
+	// harus dipanggil saat client selesai.
+	Subscribe(ctx context.Context, showtimeID uint) (<-chan model.Event, func(), error)
+	Publish(e model.Event)
+	NotifySeatsChanged(ctx context.Context, change reservationService.SeatChange)
+}
+
+type seatStreamService struct {
+	showtimeRepo showtimeRepository.ShowtimeRepository
+
+	mu          sync.Mutex
+	subscribers map[uint]map[chan model.Event]struct{}
+}
+
+func NewSeatStreamService(showtimeRepo showtimeRepository.ShowtimeRepository) SeatStreamService {
+	return &seatStreamService{
+		showtimeRepo: showtimeRepo,
+		subscribers:  make(map[uint]map[chan model.Event]struct{}),
+	}
+}
+
+func (s *seatStreamService) Subscribe(ctx context.Context, showtimeID uint) (<-chan model.Event, func(), error) {
+	if _, err := s.showtimeRepo.GetByID(ctx, showtimeID); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan model.Event, subscriberBuffer)
+
+	s.mu.Lock()
+	if s.subscribers[showtimeID] == nil {
+		s.subscribers[showtimeID] = make(map[chan model.Event]struct{})
+	}
+	s.subscribers[showtimeID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() { s.unsubscribe(showtimeID, ch) }, nil
+}
+
+func (s *seatStreamService) unsubscribe(showtimeID uint, ch chan model.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(showtimeID, ch)
+}
+
+// remove harus dipanggil dengan mu terkunci.
+func (s *seatStreamService) remove(showtimeID uint, ch chan model.Event) {
+	subs, ok := s.subscribers[showtimeID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[ch]; !ok {
+		return
+	}
+	delete(subs, ch)
+	close(ch)
+	if len(subs) == 0 {
+		delete(s.subscribers, showtimeID)
+	}
+}
+
+// Publish tidak pernah blocking.
+func (s *seatStreamService) Publish(e model.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers[e.ShowtimeID] {
+		select {
+		case ch <- e:
+		default:
+			utils.ErrorLogger.Printf("Seat stream client too slow, disconnecting (showtime: %d)", e.ShowtimeID)
+			s.remove(e.ShowtimeID, ch)
+		}
+	}
+}
+
+// NotifySeatsChanged dipasang sebagai listener perubahan seat di reservation service.
+func (s *seatStreamService) NotifySeatsChanged(_ context.Context, change reservationService.SeatChange) {
+	eventType := model.EventReleased
+	switch change.Status {
+	case seatModel.ShowtimeSeatHeld:
+		eventType = model.EventHeld
+	case seatModel.ShowtimeSeatBooked:
+		eventType = model.EventBooked
+	}
+	s.Publish(model.Event{
+		Type:       eventType,
+		ShowtimeID: change.ShowtimeID,
+		SeatIDs:    change.SeatIDs,
+		At:         time.Now(),
+	})
+}
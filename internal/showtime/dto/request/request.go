@@ -7,6 +7,7 @@ type CreateShowtimeRequest struct {
 	CinemaHallID uint      `json:"cinema_hall_id" binding:"required"`
 	StartTime    time.Time `json:"start_time" binding:"required"`
 	EndTime      time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
+	BasePrice    int64     `json:"base_price" binding:"min=0"`
 }
 
 type UpdateShowtimeRequest struct {
@@ -14,4 +15,11 @@ type UpdateShowtimeRequest struct {
 	CinemaHallID uint      `json:"cinema_hall_id" binding:"required"`
 	StartTime    time.Time `json:"start_time" binding:"required"`
 	EndTime      time.Time `json:"end_time" binding:"required,gtfield=StartTime"`
+	// BasePrice kosong berarti harga tidak diubah; reservation yang sudah
+	// dibuat tetap memakai harga saat dipesan
+	BasePrice *int64 `json:"base_price" binding:"omitempty,min=0"`
+}
+
+type CancelShowtimeRequest struct {
+	Reason string `json:"reason" binding:"max=255"`
 }
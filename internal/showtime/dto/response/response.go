@@ -8,4 +8,41 @@ type ShowtimeResponse struct {
 	CinemaHallID uint      `json:"cinema_hall_id"`
 	StartTime    time.Time `json:"start_time"`
 	EndTime      time.Time `json:"end_time"`
+	Status       string    `json:"status"`
+	BasePrice    int64     `json:"base_price"`
+
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty"`
+	CancelReason string     `json:"cancel_reason,omitempty"`
+}
+
+type CancelShowtimeResponse struct {
+	Showtime              ShowtimeResponse `json:"showtime"`
+	CancelledReservations int              `json:"cancelled_reservations"`
+	RefundedReservations  int              `json:"refunded_reservations"`
+}
+
+// SeatMapResponse adalah denah seat showtime untuk seat picker, row diurutkan
+// dari depan ke belakang dan seat dari kiri ke kanan.
+type SeatMapResponse struct {
+	ShowtimeID   uint           `json:"showtime_id"`
+	CinemaHallID uint           `json:"cinema_hall_id"`
+	Status       string         `json:"status"`
+	Summary      map[string]int `json:"summary"`
+	Rows         []SeatMapRow   `json:"rows"`
+}
+
+type SeatMapRow struct {
+	Row   string        `json:"row"`
+	Seats []SeatMapSeat `json:"seats"`
+}
+
+type SeatMapSeat struct {
+	ID uint `json:"id"`
+	// Price adalah harga tiket adult untuk seat ini
+	Price      int64  `json:"price"`
+	SeatNumber string `json:"seat_number"`
+	Category   string `json:"category"`
+	// Capacity adalah jumlah orang per seat, misalnya 2 untuk couple seat
+	Capacity int    `json:"capacity"`
+	State    string `json:"state"`
 }
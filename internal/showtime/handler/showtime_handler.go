@@ -1,13 +1,18 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/repository"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/service"
 	"github.com/didanslmn/movie-reservation-system.git/utils"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type ShowtimeHandler struct {
@@ -49,6 +54,23 @@ func (h *ShowtimeHandler) GetShowtimeByID(c *gin.Context) {
 	utils.RespondWithSuccess(c, "Showtime fetched successfully", showtime)
 }
 
+// GetSeatMap mengembalikan denah seat showtime untuk seat picker.
+func (h *ShowtimeHandler) GetSeatMap(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid showtime ID", err)
+		return
+	}
+
+	seatMap, err := h.showtimeService.GetSeatMap(c.Request.Context(), uint(id))
+	if err != nil {
+		respondShowtimeError(c, "Failed to fetch seat map", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, "Seat map fetched successfully", seatMap)
+}
+
 func (h *ShowtimeHandler) GetAllShowtimes(c *gin.Context) {
 	showtimes, err := h.showtimeService.GetAllShowtimes(c.Request.Context())
 	if err != nil {
@@ -71,9 +93,14 @@ func (h *ShowtimeHandler) UpdateShowtime(c *gin.Context) {
 		return
 	}
 
-	showtime, err := h.showtimeService.UpdateShowtime(c.Request.Context(), uint(id), req)
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	showtime, err := h.showtimeService.UpdateShowtime(c.Request.Context(), user, uint(id), req)
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to update showtime", err)
+		respondShowtimeError(c, "Failed to update showtime", err)
 		return
 	}
 
@@ -88,9 +115,50 @@ func (h *ShowtimeHandler) DeleteShowtime(c *gin.Context) {
 	}
 	err = h.showtimeService.DeleteShowtime(c.Request.Context(), uint(id))
 	if err != nil {
-		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete showtime", err)
+		respondShowtimeError(c, "Failed to delete showtime", err)
 		return
 	}
 
 	utils.RespondWithSuccess(c, "Showtime deleted successfully", nil)
 }
+
+// CancelShowtime membatalkan showtime dan semua reservation aktifnya.
+func (h *ShowtimeHandler) CancelShowtime(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid showtime ID", err)
+		return
+	}
+
+	var req request.CancelShowtimeRequest
+	if !utils.BindAndValidate(c, &req) {
+		return
+	}
+
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.showtimeService.CancelShowtime(c.Request.Context(), user, uint(id), req)
+	if err != nil {
+		respondShowtimeError(c, "Failed to cancel showtime", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, "Showtime cancelled successfully", res)
+}
+
+// respondShowtimeError memetakan error service ke status HTTP yang sesuai.
+func respondShowtimeError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrShowtimeHasBookings):
+		utils.RespondWithError(c, http.StatusConflict, "Showtime has active bookings, cancel it instead", err)
+	case errors.Is(err, model.ErrShowtimeCancelled):
+		utils.RespondWithError(c, http.StatusConflict, msg, err)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, "Showtime not found", err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, msg, err)
+	}
+}
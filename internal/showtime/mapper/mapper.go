@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/dto/response"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
 )
@@ -12,5 +13,44 @@ func ToShowtimeResponse(s *model.Showtime) *response.ShowtimeResponse {
 		CinemaHallID: s.CinemaHallID,
 		StartTime:    s.StartTime,
 		EndTime:      s.EndTime,
+		Status:       s.Status,
+		BasePrice:    s.BasePrice,
+		CancelledAt:  s.CancelledAt,
+		CancelReason: s.CancelReason,
 	}
 }
+
+// ToSeatMapResponse mengelompokkan seat per row. seats harus sudah diurutkan;
+// prices memetakan seat ID ke harga tiket adult.
+func ToSeatMapResponse(s *model.Showtime, seats []seatModel.ShowtimeSeat, prices map[uint]int64) *response.SeatMapResponse {
+	res := &response.SeatMapResponse{
+		ShowtimeID:   s.ID,
+		CinemaHallID: s.CinemaHallID,
+		Status:       s.Status,
+		Summary: map[string]int{
+			model.SeatStateAvailable: 0,
+			model.SeatStateHeld:      0,
+			model.SeatStateBooked:    0,
+			model.SeatStateBlocked:   0,
+		},
+		Rows: []response.SeatMapRow{},
+	}
+	for _, ss := range seats {
+		state := model.SeatState(ss)
+		res.Summary[state]++
+
+		if n := len(res.Rows); n == 0 || res.Rows[n-1].Row != ss.Seat.Row {
+			res.Rows = append(res.Rows, response.SeatMapRow{Row: ss.Seat.Row})
+		}
+		row := &res.Rows[len(res.Rows)-1]
+		row.Seats = append(row.Seats, response.SeatMapSeat{
+			ID:         ss.SeatID,
+			SeatNumber: ss.Seat.SeatNumber,
+			Category:   ss.Seat.Category,
+			Capacity:   ss.Seat.Capacity(),
+			State:      state,
+			Price:      prices[ss.SeatID],
+		})
+	}
+	return res
+}
@@ -0,0 +1,26 @@
+package model
+
+import seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+
+// State seat di seat map showtime.
+const (
+	SeatStateAvailable = "available"
+	SeatStateHeld      = "held"
+	SeatStateBooked    = "booked"
+	SeatStateBlocked   = "blocked"
+)
+
+// SeatState menggabungkan status seat fisik dan inventori showtime. Seat yang
+// dinonaktifkan admin (rusak, maintenance) tampil sebagai blocked.
+func SeatState(ss seatModel.ShowtimeSeat) string {
+	if ss.Seat.Status != "" && ss.Seat.Status != "available" {
+		return SeatStateBlocked
+	}
+	switch ss.Status {
+	case seatModel.ShowtimeSeatHeld:
+		return SeatStateHeld
+	case seatModel.ShowtimeSeatBooked:
+		return SeatStateBooked
+	}
+	return SeatStateAvailable
+}
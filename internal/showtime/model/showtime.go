@@ -1,6 +1,7 @@
 package model
 
 import (
+	"errors"
 	"time"
 
 	cinemaHallModel "github.com/didanslmn/movie-reservation-system.git/internal/cinemahall/model"
@@ -8,6 +9,15 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	StatusScheduled = "scheduled"
+	StatusCancelled = "cancelled"
+)
+
+// ErrShowtimeCancelled dikembalikan saat showtime yang sudah dibatalkan
+// dipakai untuk booking, waitlist, atau perubahan jadwal.
+var ErrShowtimeCancelled = errors.New("showtime is cancelled")
+
 type Showtime struct {
 	gorm.Model
 	MovieID      uint                       `gorm:"not null"`
@@ -16,4 +26,11 @@ type Showtime struct {
 	CinemaHall   cinemaHallModel.CinemaHall `gorm:"foreignKey:CinemaHallID"`
 	StartTime    time.Time                  `gorm:"not null"`
 	EndTime      time.Time                  `gorm:"not null"`
+	Status       string                     `gorm:"type:varchar(20);not null;default:'scheduled'"`
+	// BasePrice adalah harga dasar tiket dalam satuan mata uang terkecil,
+	// disesuaikan pricing per seat dan jenis tiket
+	BasePrice int64 `gorm:"not null;default:0"`
+
+	CancelledAt  *time.Time
+	CancelReason string `gorm:"type:varchar(255)"`
 }
@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
 	"github.com/didanslmn/movie-reservation-system.git/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ShowtimeRepository interface {
@@ -15,6 +19,7 @@ type ShowtimeRepository interface {
 	GetAll(ctx context.Context) ([]model.Showtime, error)
 	Update(ctx context.Context, showtime *model.Showtime) error
 	Delete(ctx context.Context, id uint) error
+	Cancel(ctx context.Context, id uint, reason string, at time.Time) (*model.Showtime, error)
 }
 
 type showtimeRepository struct {
@@ -25,12 +30,20 @@ func NewShowtimeRepository(db *gorm.DB) ShowtimeRepository {
 	return &showtimeRepository{db: db}
 }
 
+var ErrShowtimeHasBookings = errors.New("showtime already has booked seats")
+
 func (r *showtimeRepository) Create(ctx context.Context, showtime *model.Showtime) error {
-	if err := r.db.WithContext(ctx).Create(showtime).Error; err != nil {
-		utils.ErrorLogger.Printf("Failed to create showtime: %v", err)
-		return fmt.Errorf("failed to create showtime: %w", err)
-	}
-	return nil
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(showtime).Error; err != nil {
+			utils.ErrorLogger.Printf("Failed to create showtime: %v", err)
+			return fmt.Errorf("failed to create showtime: %w", err)
+		}
+		if err := createShowtimeSeats(tx, showtime); err != nil {
+			utils.ErrorLogger.Printf("Failed to create seat inventory for showtime %d: %v", showtime.ID, err)
+			return fmt.Errorf("failed to create seat inventory: %w", err)
+		}
+		return nil
+	})
 }
 
 func (r *showtimeRepository) GetByID(ctx context.Context, id uint) (*model.Showtime, error) {
@@ -54,17 +67,105 @@ func (r *showtimeRepository) GetAll(ctx context.Context) ([]model.Showtime, erro
 }
 
 func (r *showtimeRepository) Update(ctx context.Context, showtime *model.Showtime) error {
-	if err := r.db.WithContext(ctx).Save(showtime).Error; err != nil {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return SaveShowtime(tx, showtime)
+	})
+}
+
+// SaveShowtime menyimpan perubahan showtime di dalam transaksi tx, dipakai juga
+// oleh repository lain yang harus mengubah showtime bersama datanya sendiri
+// (misalnya penandaan reschedule reservation).
+func SaveShowtime(tx *gorm.DB, showtime *model.Showtime) error {
+	var current model.Showtime
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "cinema_hall_id", "status").First(&current, showtime.ID).Error; err != nil {
+		utils.ErrorLogger.Printf("Failed to get showtime (ID: %d): %v", showtime.ID, err)
+		return fmt.Errorf("failed to get showtime: %w", err)
+	}
+	// showtime bisa dibatalkan setelah dibaca pemanggil; pembatalan tidak boleh tertimpa
+	if current.Status == model.StatusCancelled {
+		return model.ErrShowtimeCancelled
+	}
+
+	if err := tx.Omit(clause.Associations).Save(showtime).Error; err != nil {
 		utils.ErrorLogger.Printf("Failed to update showtime (ID: %d): %v", showtime.ID, err)
 		return fmt.Errorf("failed to update showtime %w", err)
 	}
+
+	if current.CinemaHallID == showtime.CinemaHallID {
+		return nil
+	}
+	// pindah hall: inventori seat dibangun ulang, hanya boleh jika belum ada booking
+	var booked int64
+	if err := tx.Model(&seatModel.ShowtimeSeat{}).
+		Where("showtime_id = ? AND status <> ?", showtime.ID, seatModel.ShowtimeSeatAvailable).
+		Count(&booked).Error; err != nil {
+		return fmt.Errorf("failed to check booked seats: %w", err)
+	}
+	if booked > 0 {
+		return ErrShowtimeHasBookings
+	}
+	if err := tx.Where("showtime_id = ?", showtime.ID).Delete(&seatModel.ShowtimeSeat{}).Error; err != nil {
+		return fmt.Errorf("failed to clear seat inventory: %w", err)
+	}
+	if err := createShowtimeSeats(tx, showtime); err != nil {
+		utils.ErrorLogger.Printf("Failed to rebuild seat inventory for showtime %d: %v", showtime.ID, err)
+		return fmt.Errorf("failed to rebuild seat inventory: %w", err)
+	}
 	return nil
 }
 
+// Delete menghapus showtime yang belum punya seat ditahan atau dibooking.
+// Showtime dengan booking aktif harus dibatalkan lewat Cancel.
 func (r *showtimeRepository) Delete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Delete(&model.Showtime{}, id).Error; err != nil {
-		utils.ErrorLogger.Printf("Failed to delete showtime (ID: %d): %v", id, err)
-		return fmt.Errorf("failed to delete showtime: %w", err)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var booked int64
+		if err := tx.Model(&seatModel.ShowtimeSeat{}).
+			Where("showtime_id = ? AND status <> ?", id, seatModel.ShowtimeSeatAvailable).
+			Count(&booked).Error; err != nil {
+			return fmt.Errorf("failed to check booked seats: %w", err)
+		}
+		if booked > 0 {
+			return ErrShowtimeHasBookings
+		}
+
+		if err := tx.Delete(&model.Showtime{}, id).Error; err != nil {
+			utils.ErrorLogger.Printf("Failed to delete showtime (ID: %d): %v", id, err)
+			return fmt.Errorf("failed to delete showtime: %w", err)
+		}
+		return nil
+	})
+}
+
+// Cancel menandai showtime dibatalkan. Showtime yang sudah dibatalkan tidak
+// diubah lagi sehingga waktu dan alasan pembatalan pertama tetap tersimpan.
+func (r *showtimeRepository) Cancel(ctx context.Context, id uint, reason string, at time.Time) (*model.Showtime, error) {
+	var showtime model.Showtime
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&showtime, id).Error; err != nil {
+			return fmt.Errorf("failed to get showtime by id: %w", err)
+		}
+		if showtime.Status == model.StatusCancelled {
+			return nil
+		}
+		showtime.Status = model.StatusCancelled
+		showtime.CancelledAt = &at
+		showtime.CancelReason = reason
+		if err := tx.Save(&showtime).Error; err != nil {
+			return fmt.Errorf("failed to cancel showtime: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to cancel showtime (ID: %d): %v", id, err)
+		return nil, err
 	}
-	return nil
+	return &showtime, nil
+}
+
+// createShowtimeSeats mengisi showtime_seats dari semua seat aktif di hall showtime.
+func createShowtimeSeats(tx *gorm.DB, showtime *model.Showtime) error {
+	return tx.Exec(`INSERT INTO showtime_seats (showtime_id, seat_id, status, created_at, updated_at)
+		SELECT ?, id, ?, NOW(), NOW() FROM seats
+		WHERE cinema_hall_id = ? AND deleted_at IS NULL`,
+		showtime.ID, seatModel.ShowtimeSeatAvailable, showtime.CinemaHallID).Error
 }
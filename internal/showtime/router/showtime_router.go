@@ -16,6 +16,7 @@ func ShowtimeRoutes(rg *gin.RouterGroup, h *handler.ShowtimeHandler, jwtSecret s
 	{
 		publicRoutes.GET("/", h.GetAllShowtimes)
 		publicRoutes.GET("/:id", h.GetShowtimeByID)
+		publicRoutes.GET("/:id/seatmap", h.GetSeatMap)
 	}
 
 	// Admin-only
@@ -26,5 +27,6 @@ func ShowtimeRoutes(rg *gin.RouterGroup, h *handler.ShowtimeHandler, jwtSecret s
 		adminRoutes.POST("/", h.CreateShowtime)
 		adminRoutes.PUT("/:id", h.UpdateShowtime)
 		adminRoutes.DELETE("/:id", h.DeleteShowtime)
+		adminRoutes.POST("/:id/cancel", h.CancelShowtime)
 	}
 }
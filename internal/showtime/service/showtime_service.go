@@ -4,14 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	hallRepository "github.com/didanslmn/movie-reservation-system.git/internal/cinemahall/repository"
 	movieRepository "github.com/didanslmn/movie-reservation-system.git/internal/movie/repository"
+	pricingService "github.com/didanslmn/movie-reservation-system.git/internal/pricing/service"
+	reservationService "github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	seatRepository "github.com/didanslmn/movie-reservation-system.git/internal/seat/repository"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/dto/request"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/dto/response"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/mapper"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
 	"github.com/didanslmn/movie-reservation-system.git/internal/showtime/repository"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
 	"github.com/didanslmn/movie-reservation-system.git/utils"
 )
 
@@ -19,21 +26,36 @@ type ShowtimeService interface {
 	CreateShowtime(ctx context.Context, req request.CreateShowtimeRequest) (*response.ShowtimeResponse, error)
 	GetShowtimeByID(ctx context.Context, id uint) (*response.ShowtimeResponse, error)
 	GetAllShowtimes(ctx context.Context) ([]response.ShowtimeResponse, error)
-	UpdateShowtime(ctx context.Context, id uint, req request.UpdateShowtimeRequest) (*response.ShowtimeResponse, error)
+	UpdateShowtime(ctx context.Context, actor *userModel.User, id uint, req request.UpdateShowtimeRequest) (*response.ShowtimeResponse, error)
 	DeleteShowtime(ctx context.Context, id uint) error
+	CancelShowtime(ctx context.Context, actor *userModel.User, id uint, req request.CancelShowtimeRequest) (*response.CancelShowtimeResponse, error)
+	GetSeatMap(ctx context.Context, id uint) (*response.SeatMapResponse, error)
 }
 
 type showtimeService struct {
-	showtimeRepo repository.ShowtimeRepository
-	movieRepo    movieRepository.MovieRepository
-	hallRepo     hallRepository.CinemaHallRepository
+	showtimeRepo   repository.ShowtimeRepository
+	movieRepo      movieRepository.MovieRepository
+	hallRepo       hallRepository.CinemaHallRepository
+	seatRepo       seatRepository.SeatRepository
+	pricingSvc     pricingService.PricingService
+	reservationSvc reservationService.ReservationService
 }
 
-func NewShowtimeService(showtimeRepo repository.ShowtimeRepository, movieRepo movieRepository.MovieRepository, hallRepo hallRepository.CinemaHallRepository) ShowtimeService {
+func NewShowtimeService(
+	showtimeRepo repository.ShowtimeRepository,
+	movieRepo movieRepository.MovieRepository,
+	hallRepo hallRepository.CinemaHallRepository,
+	seatRepo seatRepository.SeatRepository,
+	pricingSvc pricingService.PricingService,
+	reservationSvc reservationService.ReservationService,
+) ShowtimeService {
 	return &showtimeService{
-		showtimeRepo: showtimeRepo,
-		movieRepo:    movieRepo,
-		hallRepo:     hallRepo,
+		showtimeRepo:   showtimeRepo,
+		movieRepo:      movieRepo,
+		hallRepo:       hallRepo,
+		seatRepo:       seatRepo,
+		pricingSvc:     pricingSvc,
+		reservationSvc: reservationSvc,
 	}
 }
 
@@ -58,6 +80,8 @@ func (s *showtimeService) CreateShowtime(ctx context.Context, req request.Create
 		CinemaHallID: req.CinemaHallID,
 		StartTime:    req.StartTime,
 		EndTime:      req.EndTime,
+		Status:       model.StatusScheduled,
+		BasePrice:    req.BasePrice,
 	}
 
 	if err := s.showtimeRepo.Create(ctx, &showtime); err != nil {
@@ -94,13 +118,18 @@ func (s *showtimeService) GetAllShowtimes(ctx context.Context) ([]response.Showt
 	return result, nil
 }
 
-// Update Showtime
-func (s *showtimeService) UpdateShowtime(ctx context.Context, id uint, req request.UpdateShowtimeRequest) (*response.ShowtimeResponse, error) {
+// Update Showtime. Jika jadwal berubah, pemegang reservation aktif diberi tahu
+// dan bisa memilih menerima jadwal baru atau membatalkan dengan refund.
+func (s *showtimeService) UpdateShowtime(ctx context.Context, actor *userModel.User, id uint, req request.UpdateShowtimeRequest) (*response.ShowtimeResponse, error) {
 	showtime, err := s.showtimeRepo.GetByID(ctx, id)
 	if err != nil {
 		utils.ErrorLogger.Printf("Showtime not found (ID: %d): %v", id, err)
 		return nil, fmt.Errorf("showtime not found: %w", err)
 	}
+	if showtime.Status == model.StatusCancelled {
+		return nil, model.ErrShowtimeCancelled
+	}
+	previous := *showtime
 
 	if req.MovieID != 0 {
 		exist, err := s.movieRepo.ExistsByID(ctx, req.MovieID)
@@ -126,8 +155,17 @@ func (s *showtimeService) UpdateShowtime(ctx context.Context, id uint, req reque
 	if !req.EndTime.IsZero() {
 		showtime.EndTime = req.EndTime
 	}
+	if req.BasePrice != nil {
+		showtime.BasePrice = *req.BasePrice
+	}
 
-	if err := s.showtimeRepo.Update(ctx, showtime); err != nil {
+	// perubahan jadwal disimpan bersama penandaan reservation dalam satu transaksi
+	if !previous.StartTime.Equal(showtime.StartTime) || !previous.EndTime.Equal(showtime.EndTime) {
+		_, err = s.reservationSvc.RescheduleShowtime(ctx, actor, &previous, showtime)
+	} else {
+		err = s.showtimeRepo.Update(ctx, showtime)
+	}
+	if err != nil {
 		utils.ErrorLogger.Printf("Failed to update showtime (ID: %d): %v", id, err)
 		return nil, fmt.Errorf("failed to update showtime: %w", err)
 	}
@@ -136,7 +174,8 @@ func (s *showtimeService) UpdateShowtime(ctx context.Context, id uint, req reque
 	return mapper.ToShowtimeResponse(showtime), nil
 }
 
-// Delete Showtime
+// Delete Showtime. Showtime dengan seat yang ditahan atau dibooking ditolak
+// (ErrShowtimeHasBookings) dan harus dibatalkan lewat CancelShowtime.
 func (s *showtimeService) DeleteShowtime(ctx context.Context, id uint) error {
 	if err := s.showtimeRepo.Delete(ctx, id); err != nil {
 		utils.ErrorLogger.Printf("Failed to delete showtime (ID: %d): %v", id, err)
@@ -145,3 +184,67 @@ func (s *showtimeService) DeleteShowtime(ctx context.Context, id uint) error {
 	utils.InfoLogger.Printf("Successfully deleted showtime (ID: %d)", id)
 	return nil
 }
+
+// CancelShowtime membatalkan showtime beserta semua reservation aktifnya:
+// seat dilepas, reservation confirmed di-refund, dan user diberi tahu lewat
+// notifikasi reservation. Aman dipanggil ulang untuk menuntaskan pembatalan
+// yang sempat gagal di tengah jalan.
+func (s *showtimeService) CancelShowtime(ctx context.Context, actor *userModel.User, id uint, req request.CancelShowtimeRequest) (*response.CancelShowtimeResponse, error) {
+	reason := req.Reason
+	if reason == "" {
+		reason = "showtime cancelled"
+	}
+
+	showtime, err := s.showtimeRepo.Cancel(ctx, id, reason, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel showtime: %w", err)
+	}
+
+	cancelled, refunded, err := s.reservationSvc.CancelShowtimeReservations(ctx, actor, id, showtime.CancelReason)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to cancel reservations of showtime %d: %v", id, err)
+		return nil, fmt.Errorf("failed to cancel reservations: %w", err)
+	}
+
+	utils.InfoLogger.Printf("Successfully cancelled showtime (ID: %d)", id)
+	return &response.CancelShowtimeResponse{
+		Showtime:              *mapper.ToShowtimeResponse(showtime),
+		CancelledReservations: cancelled,
+		RefundedReservations:  refunded,
+	}, nil
+}
+
+// GetSeatMap mengembalikan denah seat showtime beserta state tiap seat.
+func (s *showtimeService) GetSeatMap(ctx context.Context, id uint) (*response.SeatMapResponse, error) {
+	showtime, err := s.showtimeRepo.GetByID(ctx, id)
+	if err != nil {
+		utils.ErrorLogger.Printf("Showtime not found (ID: %d): %v", id, err)
+		return nil, fmt.Errorf("showtime not found: %w", err)
+	}
+
+	seats, err := s.seatRepo.GetShowtimeSeats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(seats, func(a, b seatModel.ShowtimeSeat) int {
+		if c := seatModel.CompareRow(a.Seat.Row, b.Seat.Row); c != 0 {
+			return c
+		}
+		return seatModel.CompareSeatNumber(a.Seat.SeatNumber, b.Seat.SeatNumber)
+	})
+
+	physical := make([]seatModel.Seat, 0, len(seats))
+	for _, ss := range seats {
+		physical = append(physical, ss.Seat)
+	}
+	quotes, err := s.pricingSvc.QuoteSeats(ctx, showtime, physical, nil)
+	if err != nil {
+		return nil, err
+	}
+	prices := make(map[uint]int64, len(quotes))
+	for _, q := range quotes {
+		prices[q.SeatID] = q.Price
+	}
+
+	return mapper.ToSeatMapResponse(showtime, seats, prices), nil
+}
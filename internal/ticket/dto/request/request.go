@@ -0,0 +1,5 @@
+package request
+
+type CheckInRequest struct {
+	Token string `json:"token" binding:"required"`
+}
@@ -0,0 +1,27 @@
+package response
+
+import "time"
+
+type TicketResponse struct {
+	ID            uint       `json:"id"`
+	ReservationID uint       `json:"reservation_id"`
+	Token         string     `json:"token"`
+	IssuedAt      time.Time  `json:"issued_at"`
+	UsedAt        *time.Time `json:"used_at,omitempty"`
+}
+
+type CheckInResponse struct {
+	TicketID      uint           `json:"ticket_id"`
+	ReservationID uint           `json:"reservation_id"`
+	UserName      string         `json:"user_name"`
+	ShowtimeID    uint           `json:"showtime_id"`
+	StartTime     time.Time      `json:"start_time"`
+	Seats         []SeatResponse `json:"seat"`
+	CheckedInAt   time.Time      `json:"checked_in_at"`
+}
+
+type SeatResponse struct {
+	ID         uint   `json:"id"`
+	SeatNumber string `json:"seat_number"`
+	Row        string `json:"row"`
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	reservationService "github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/service"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TicketHandler struct {
+	service service.TicketService
+}
+
+func NewTicketHandler(service service.TicketService) *TicketHandler {
+	return &TicketHandler{service: service}
+}
+
+func (h *TicketHandler) GetTicket(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.GetTicket(c.Request.Context(), user, id)
+	if err != nil {
+		respondTicketError(c, "Failed to fetch ticket", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Ticket fetched successfully", res)
+}
+
+// GetTicketQR mengembalikan token tiket sebagai gambar QR PNG.
+func (h *TicketHandler) GetTicketQR(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	png, err := h.service.GetTicketQR(c.Request.Context(), user, id)
+	if err != nil {
+		respondTicketError(c, "Failed to render ticket", err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+func (h *TicketHandler) GetTicketPDF(c *gin.Context) {
+	user, id, ok := userAndReservationID(c)
+	if !ok {
+		return
+	}
+
+	pdf, err := h.service.GetTicketPDF(c.Request.Context(), user, id)
+	if err != nil {
+		respondTicketError(c, "Failed to render ticket", err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="ticket-%d.pdf"`, id))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// GetGuestTicket, GetGuestTicketQR, dan GetGuestTicketPDF melayani tiket
+// guest lewat token magic link.
+func (h *TicketHandler) GetGuestTicket(c *gin.Context) {
+	res, err := h.service.GetGuestTicket(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondTicketError(c, "Failed to fetch ticket", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Ticket fetched successfully", res)
+}
+
+func (h *TicketHandler) GetGuestTicketQR(c *gin.Context) {
+	png, err := h.service.GetGuestTicketQR(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondTicketError(c, "Failed to render ticket", err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "image/png", png)
+}
+
+func (h *TicketHandler) GetGuestTicketPDF(c *gin.Context) {
+	pdf, err := h.service.GetGuestTicketPDF(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		respondTicketError(c, "Failed to render ticket", err)
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="ticket.pdf"`)
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// CheckIn dipakai staff di pintu masuk untuk memindai QR tiket.
+func (h *TicketHandler) CheckIn(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var req request.CheckInRequest
+	if !utils.BindAndValidate(c, &req) {
+		return
+	}
+
+	res, err := h.service.CheckIn(c.Request.Context(), user, req.Token)
+	if err != nil {
+		respondTicketError(c, "Check-in rejected", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Checked in successfully", res)
+}
+
+func userAndReservationID(c *gin.Context) (*userModel.User, uint, bool) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return nil, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid reservation ID", err)
+		return nil, 0, false
+	}
+	return user, uint(id), true
+}
+
+func respondTicketError(c *gin.Context, msg string, err error) {
+	var transition *reservationModel.TransitionError
+	switch {
+	case errors.Is(err, service.ErrInvalidTicket):
+		utils.RespondWithError(c, http.StatusBadRequest, msg, err)
+	case errors.Is(err, service.ErrForbidden):
+		utils.RespondWithError(c, http.StatusForbidden, msg, err)
+	case errors.Is(err, service.ErrTicketAlreadyUsed), errors.Is(err, service.ErrOutsideCheckInWindow),
+		errors.Is(err, service.ErrTicketNotAvailable), errors.As(err, &transition):
+		utils.RespondWithError(c, http.StatusConflict, msg, err)
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, reservationService.ErrInvalidAccessToken):
+		utils.RespondWithError(c, http.StatusNotFound, "Reservation not found", err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, msg, err)
+	}
+}
@@ -0,0 +1,39 @@
+package mapper
+
+import (
+	"time"
+
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/model"
+)
+
+func ToTicketResponse(t *model.Ticket, token string) *response.TicketResponse {
+	return &response.TicketResponse{
+		ID:            t.ID,
+		ReservationID: t.ReservationID,
+		Token:         token,
+		IssuedAt:      t.IssuedAt,
+		UsedAt:        t.UsedAt,
+	}
+}
+
+func ToCheckInResponse(t *model.Ticket, r *reservationModel.Reservation, checkedInAt time.Time) *response.CheckInResponse {
+	seats := make([]response.SeatResponse, 0, len(r.Seats))
+	for _, seat := range r.Seats {
+		seats = append(seats, response.SeatResponse{
+			ID:         seat.ID,
+			SeatNumber: seat.SeatNumber,
+			Row:        seat.Row,
+		})
+	}
+	return &response.CheckInResponse{
+		TicketID:      t.ID,
+		ReservationID: r.ID,
+		UserName:      r.User.Name,
+		ShowtimeID:    r.ShowtimeID,
+		StartTime:     r.Showtime.StartTime,
+		Seats:         seats,
+		CheckedInAt:   checkedInAt,
+	}
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Ticket adalah tiket masuk untuk satu reservation yang sudah dikonfirmasi.
+// Nonce ikut ditandatangani di token QR sehingga tiket bisa dicabut dengan
+// mengganti nonce. UsedAt terisi saat tiket dipakai check-in.
+type Ticket struct {
+	ID            uint      `gorm:"primaryKey"`
+	ReservationID uint      `gorm:"not null;uniqueIndex"`
+	Nonce         string    `gorm:"type:varchar(64);not null"`
+	IssuedAt      time.Time `gorm:"not null"`
+	UsedAt        *time.Time
+	UsedBy        *uint
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	reservationRepository "github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TicketRepository interface {
+	GetByID(ctx context.Context, id uint) (*model.Ticket, error)
+	GetByReservationID(ctx context.Context, reservationID uint) (*model.Ticket, error)
+	Issue(ctx context.Context, ticket *model.Ticket) (*model.Ticket, error)
+	CheckIn(ctx context.Context, id, usedBy uint, usedAt time.Time, event *reservationModel.ReservationEvent, check func(t *model.Ticket, r *reservationModel.Reservation) error) error
+}
+
+type ticketRepository struct {
+	db *gorm.DB
+}
+
+func NewTicketRepository(db *gorm.DB) TicketRepository {
+	return &ticketRepository{db: db}
+}
+
+func (r *ticketRepository) GetByID(ctx context.Context, id uint) (*model.Ticket, error) {
+	var ticket model.Ticket
+	if err := r.db.WithContext(ctx).First(&ticket, id).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to get ticket (ID: %d): %v", id, err)
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	return &ticket, nil
+}
+
+// GetByReservationID mengembalikan nil tanpa error jika tiket belum diterbitkan.
+func (r *ticketRepository) GetByReservationID(ctx context.Context, reservationID uint) (*model.Ticket, error) {
+	var ticket model.Ticket
+	err := r.db.WithContext(ctx).Where("reservation_id = ?", reservationID).First(&ticket).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		utils.ErrorLogger.Printf("Error to get ticket (reservation: %d): %v", reservationID, err)
+		return nil, fmt.Errorf("failed to get ticket: %w", err)
+	}
+	return &ticket, nil
+}
+
+// Issue menyimpan tiket baru. Jika request lain sudah lebih dulu menerbitkan
+// tiket untuk reservation yang sama, tiket yang sudah ada yang dikembalikan.
+func (r *ticketRepository) Issue(ctx context.Context, ticket *model.Ticket) (*model.Ticket, error) {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "reservation_id"}}, DoNothing: true}).
+		Create(ticket).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to issue ticket (reservation: %d): %v", ticket.ReservationID, err)
+		return nil, fmt.Errorf("failed to issue ticket: %w", err)
+	}
+	return r.GetByReservationID(ctx, ticket.ReservationID)
+}
+
+// CheckIn memakai tiket dan mengubah reservation-nya menjadi checked_in dalam
+// satu transaksi. Tiket dan reservation dikunci lebih dulu, lalu check
+// dipanggil dengan data yang sudah dikunci sebelum perubahan disimpan.
+func (r *ticketRepository) CheckIn(ctx context.Context, id, usedBy uint, usedAt time.Time, event *reservationModel.ReservationEvent, check func(t *model.Ticket, r *reservationModel.Reservation) error) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ticket model.Ticket
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&ticket, id).Error; err != nil {
+			return fmt.Errorf("failed to get ticket: %w", err)
+		}
+		_, err := reservationRepository.TransitionLocked(tx, ticket.ReservationID, reservationModel.ActionCheckIn, event, func(res *reservationModel.Reservation) error {
+			return check(&ticket, res)
+		})
+		if err != nil {
+			return err
+		}
+		err = tx.Model(&ticket).Updates(map[string]any{"used_at": usedAt, "used_by": usedBy}).Error
+		if err != nil {
+			return fmt.Errorf("failed to mark ticket used: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to check in ticket (ID: %d): %v", id, err)
+		return err
+	}
+	return nil
+}
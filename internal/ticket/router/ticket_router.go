@@ -0,0 +1,39 @@
+package router
+
+import (
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/handler"
+	"github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/gin-gonic/gin"
+)
+
+func TicketRoutes(rg *gin.RouterGroup, h *handler.TicketHandler, jwtSecret string) {
+	// User & Admin dapat mengambil tiket reservation yang sudah dikonfirmasi
+	tickets := rg.Group("/reservations")
+	tickets.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	tickets.Use(middleware.RoleBasedAccess(model.RoleUser, model.RoleAdmin))
+	{
+		tickets.GET("/:id/ticket", h.GetTicket)
+		tickets.GET("/:id/ticket/qr", h.GetTicketQR)
+		tickets.GET("/:id/ticket.pdf", h.GetTicketPDF)
+	}
+
+	// Staff & Admin memindai tiket di pintu masuk
+	checkin := rg.Group("/checkin")
+	checkin.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	checkin.Use(middleware.RoleBasedAccess(model.RoleStaff, model.RoleAdmin))
+	{
+		checkin.POST("", h.CheckIn)
+	}
+}
+
+// GuestTicketRoutes didaftarkan tanpa JWT; tiket guest diakses lewat token
+// magic link seperti reservation-nya.
+func GuestTicketRoutes(rg *gin.RouterGroup, h *handler.TicketHandler) {
+	guest := rg.Group("/guest/reservations")
+	{
+		guest.GET("/:token/ticket", h.GetGuestTicket)
+		guest.GET("/:token/ticket/qr", h.GetGuestTicketQR)
+		guest.GET("/:token/ticket.pdf", h.GetGuestTicketPDF)
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/model"
+	"github.com/go-pdf/fpdf"
+)
+
+// renderTicketPDF membuat e-ticket A5 berisi detail showtime, seat, dan QR tiket.
+func renderTicketPDF(reservation *reservationModel.Reservation, ticket *model.Ticket, qrPNG []byte) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A5", "")
+	pdf.SetTitle(fmt.Sprintf("Ticket #%d", ticket.ID), true)
+	pdf.SetMargins(12, 12, 12)
+	pdf.SetAutoPageBreak(true, 12)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	width, _ := pdf.GetPageSize()
+	contentWidth := width - 24
+
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.CellFormat(contentWidth, 6, "E-TICKET", "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.MultiCell(contentWidth, 8, tr(reservation.Showtime.Movie.Title), "", "L", false)
+	pdf.Ln(2)
+
+	start := reservation.Showtime.StartTime
+	rows := [][2]string{
+		{"Hall", reservation.Showtime.CinemaHall.Name},
+		{"Date", start.Format("Monday, 02 January 2006")},
+		{"Time", fmt.Sprintf("%s - %s", start.Format("15:04"), reservation.Showtime.EndTime.Format("15:04"))},
+		{"Seats", formatSeats(reservation)},
+		{"Name", reservation.User.Name},
+		{"Booking", fmt.Sprintf("#%d", reservation.ID)},
+	}
+	for _, row := range rows {
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(25, 7, row[0], "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.MultiCell(contentWidth-25, 7, tr(row[1]), "", "L", false)
+	}
+	pdf.Ln(4)
+
+	const qrSize = 60
+	pdf.RegisterImageOptionsReader("qr", fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(qrPNG))
+	pdf.ImageOptions("qr", (width-qrSize)/2, pdf.GetY(), qrSize, qrSize, true, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+	pdf.Ln(2)
+
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.MultiCell(contentWidth, 5, "Show this QR code at the entrance. The ticket is valid for one check-in only.", "", "C", false)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatSeats mengelompokkan seat per row, misalnya "A: 5, 6 / B: 1".
+func formatSeats(reservation *reservationModel.Reservation) string {
+	byRow := make(map[string][]string)
+	rows := make([]string, 0)
+	for _, seat := range reservation.Seats {
+		if _, ok := byRow[seat.Row]; !ok {
+			rows = append(rows, seat.Row)
+		}
+		byRow[seat.Row] = append(byRow[seat.Row], seat.SeatNumber)
+	}
+	sort.Strings(rows)
+
+	parts := make([]string, 0, len(rows))
+	for _, row := range rows {
+		parts = append(parts, fmt.Sprintf("%s: %s", row, strings.Join(byRow[row], ", ")))
+	}
+	return strings.Join(parts, " / ")
+}
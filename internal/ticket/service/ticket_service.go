@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/didanslmn/movie-reservation-system.git/config"
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	reservationRepository "github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	reservationService "github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/ticket/repository"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	qrcode "github.com/skip2/go-qrcode"
+	"gorm.io/gorm"
+)
+
+// QRSize adalah ukuran sisi gambar QR dalam pixel
+const QRSize = 256
+
+var (
+	ErrForbidden            = errors.New("reservation belongs to another user")
+	ErrTicketNotAvailable   = errors.New("ticket is only available for confirmed reservations")
+	ErrInvalidTicket        = errors.New("invalid ticket")
+	ErrTicketAlreadyUsed    = errors.New("ticket already used")
+	ErrOutsideCheckInWindow = errors.New("check-in is not open for this showtime")
+)
+
+type TicketService interface {
+	GetTicket(ctx context.Context, actor *userModel.User, reservationID uint) (*response.TicketResponse, error)
+	GetTicketQR(ctx context.Context, actor *userModel.User, reservationID uint) ([]byte, error)
+	GetTicketPDF(ctx context.Context, actor *userModel.User, reservationID uint) ([]byte, error)
+	// GetGuestTicket, GetGuestTicketQR, dan GetGuestTicketPDF melayani guest
+	// lewat token magic link, bukan JWT
+	GetGuestTicket(ctx context.Context, accessToken string) (*response.TicketResponse, error)
+	GetGuestTicketQR(ctx context.Context, accessToken string) ([]byte, error)
+	GetGuestTicketPDF(ctx context.Context, accessToken string) ([]byte, error)
+	CheckIn(ctx context.Context, staff *userModel.User, token string) (*response.CheckInResponse, error)
+}
+
+type ticketService struct {
+	ticketRepo      repository.TicketRepository
+	reservationRepo reservationRepository.ReservationRepository
+	reservationSvc  reservationService.ReservationService
+	guestSvc        reservationService.GuestService
+	cfg             config.TicketConfig
+}
+
+func NewTicketService(
+	ticketRepo repository.TicketRepository,
+	reservationRepo reservationRepository.ReservationRepository,
+	reservationSvc reservationService.ReservationService,
+	guestSvc reservationService.GuestService,
+	cfg config.TicketConfig,
+) TicketService {
+	return &ticketService{
+		ticketRepo:      ticketRepo,
+		reservationRepo: reservationRepo,
+		reservationSvc:  reservationSvc,
+		guestSvc:        guestSvc,
+		cfg:             cfg,
+	}
+}
+
+func (s *ticketService) GetTicket(ctx context.Context, actor *userModel.User, reservationID uint) (*response.TicketResponse, error) {
+	_, ticket, token, err := s.ticketFor(ctx, actor, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToTicketResponse(ticket, token), nil
+}
+
+func (s *ticketService) GetTicketQR(ctx context.Context, actor *userModel.User, reservationID uint) ([]byte, error) {
+	_, _, token, err := s.ticketFor(ctx, actor, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	png, err := qrcode.Encode(token, qrcode.Medium, QRSize)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to render ticket QR (reservation: %d): %v", reservationID, err)
+		return nil, fmt.Errorf("failed to render ticket QR: %w", err)
+	}
+	return png, nil
+}
+
+// GetTicketPDF membuat e-ticket yang bisa dicetak, lengkap dengan QR tiket.
+func (s *ticketService) GetTicketPDF(ctx context.Context, actor *userModel.User, reservationID uint) ([]byte, error) {
+	reservation, ticket, token, err := s.ticketFor(ctx, actor, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	png, err := qrcode.Encode(token, qrcode.Medium, QRSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render ticket QR: %w", err)
+	}
+
+	pdf, err := renderTicketPDF(reservation, ticket, png)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to render ticket PDF (reservation: %d): %v", reservationID, err)
+		return nil, fmt.Errorf("failed to render ticket PDF: %w", err)
+	}
+	return pdf, nil
+}
+
+func (s *ticketService) GetGuestTicket(ctx context.Context, accessToken string) (*response.TicketResponse, error) {
+	guest, reservationID, err := s.resolveGuest(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTicket(ctx, guest, reservationID)
+}
+
+func (s *ticketService) GetGuestTicketQR(ctx context.Context, accessToken string) ([]byte, error) {
+	guest, reservationID, err := s.resolveGuest(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTicketQR(ctx, guest, reservationID)
+}
+
+func (s *ticketService) GetGuestTicketPDF(ctx context.Context, accessToken string) ([]byte, error) {
+	guest, reservationID, err := s.resolveGuest(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetTicketPDF(ctx, guest, reservationID)
+}
+
+// resolveGuest mengembalikan pemilik dan ID reservation dari token magic link.
+func (s *ticketService) resolveGuest(ctx context.Context, accessToken string) (*userModel.User, uint, error) {
+	reservation, err := s.guestSvc.ResolveAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &reservation.User, reservation.ID, nil
+}
+
+// CheckIn memverifikasi token tiket, memastikan showtime sedang dalam window
+// check-in, lalu memakai tiket tepat satu kali dan mengubah reservation
+// menjadi checked_in.
+func (s *ticketService) CheckIn(ctx context.Context, staff *userModel.User, token string) (*response.CheckInResponse, error) {
+	claims, err := verifyTicket(s.cfg.Secret, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	event := &reservationModel.ReservationEvent{ActorID: &staff.ID, ActorRole: string(staff.Role)}
+	var ticket model.Ticket
+	err = s.ticketRepo.CheckIn(ctx, claims.TicketID, staff.ID, now, event, func(t *model.Ticket, r *reservationModel.Reservation) error {
+		if t.ReservationID != claims.ReservationID || t.Nonce != claims.Nonce {
+			return ErrInvalidTicket
+		}
+		if t.UsedAt != nil {
+			return fmt.Errorf("%w at %s", ErrTicketAlreadyUsed, t.UsedAt.Format(time.RFC3339))
+		}
+		if r.Status != reservationModel.StatusConfirmed {
+			return fmt.Errorf("%w: reservation is %s", ErrInvalidTicket, r.Status)
+		}
+		opensAt := r.Showtime.StartTime.Add(-s.cfg.CheckInOpensBefore)
+		if now.Before(opensAt) {
+			return fmt.Errorf("%w: opens at %s", ErrOutsideCheckInWindow, opensAt.Format(time.RFC3339))
+		}
+		if now.After(r.Showtime.EndTime) {
+			return fmt.Errorf("%w: showtime already ended", ErrOutsideCheckInWindow)
+		}
+		ticket = *t
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidTicket
+		}
+		return nil, err
+	}
+	s.reservationSvc.PublishStatusChanged(ticket.ReservationID)
+
+	reservation, err := s.reservationRepo.GetByID(ctx, ticket.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	utils.InfoLogger.Printf("Ticket %d checked in by staff %d (reservation: %d)", ticket.ID, staff.ID, reservation.ID)
+	return mapper.ToCheckInResponse(&ticket, reservation, now), nil
+}
+
+// ticketFor mengembalikan reservation, tiketnya, dan token bertanda tangan.
+// Tiket baru diterbitkan jika belum ada.
+func (s *ticketService) ticketFor(ctx context.Context, actor *userModel.User, reservationID uint) (*reservationModel.Reservation, *model.Ticket, string, error) {
+	reservation, err := s.reservationRepo.GetByID(ctx, reservationID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if actor.Role != userModel.RoleAdmin && reservation.UserID != actor.ID {
+		return nil, nil, "", ErrForbidden
+	}
+	if reservation.Status != reservationModel.StatusConfirmed && reservation.Status != reservationModel.StatusCheckedIn {
+		return nil, nil, "", ErrTicketNotAvailable
+	}
+
+	ticket, err := s.ticketRepo.GetByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if ticket == nil {
+		nonce, err := newNonce()
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to generate ticket nonce: %w", err)
+		}
+		ticket, err = s.ticketRepo.Issue(ctx, &model.Ticket{
+			ReservationID: reservationID,
+			Nonce:         nonce,
+			IssuedAt:      time.Now(),
+		})
+		if err != nil {
+			return nil, nil, "", err
+		}
+		utils.InfoLogger.Printf("Ticket issued (ID: %d, reservation: %d)", ticket.ID, reservationID)
+	}
+
+	token, err := signTicket(s.cfg.Secret, ticketClaims{
+		TicketID:      ticket.ID,
+		ReservationID: ticket.ReservationID,
+		Nonce:         ticket.Nonce,
+	})
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to sign ticket: %w", err)
+	}
+	return reservation, ticket, token, nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+)
+
+// ticketClaims adalah isi token tiket. Token berbentuk
+// base64url(payload) + "." + base64url(HMAC-SHA256(payload)).
+type ticketClaims struct {
+	TicketID      uint   `json:"tid"`
+	ReservationID uint   `json:"rid"`
+	Nonce         string `json:"n"`
+}
+
+func signTicket(secret string, claims ticketClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(ticketMAC(secret, encoded)), nil
+}
+
+func verifyTicket(secret, token string) (*ticketClaims, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidTicket
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, ticketMAC(secret, encoded)) {
+		return nil, ErrInvalidTicket
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidTicket
+	}
+	var claims ticketClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidTicket
+	}
+	return &claims, nil
+}
+
+func ticketMAC(secret, encoded string) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
+
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
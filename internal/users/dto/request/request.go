@@ -4,6 +4,9 @@ type RegisterRequest struct {
 	Name     string `json:"name" binding:"required"`
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=8"`
+	// GuestAccessToken opsional: token magic link guest checkout dengan email
+	// yang sama, agar akun guest beserta reservation-nya dijadikan akun ini
+	GuestAccessToken string `json:"guest_access_token"`
 }
 
 type LoginRequest struct {
@@ -19,3 +22,7 @@ type ChangePasswordRequest struct {
 	OldPassword string `json:"old_password" binding:"required"`
 	NewPassword string `json:"new_password" binding:"required,min=8"`
 }
+
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin user staff"`
+}
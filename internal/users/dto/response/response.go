@@ -9,3 +9,10 @@ type AuthResponse struct {
 	Role  model.Role `json:"role"`
 	Token string     `json:"token"`
 }
+
+type UserResponse struct {
+	ID    uint       `json:"id"`
+	Name  string     `json:"name"`
+	Email string     `json:"email"`
+	Role  model.Role `json:"role"`
+}
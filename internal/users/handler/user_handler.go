@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
 	"github.com/didanslmn/movie-reservation-system.git/internal/users/dto/request"
@@ -110,3 +111,24 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 
 	utils.RespondWithSuccess(c, "Password changed successfully", nil)
 }
+
+func (h *UserHandler) UpdateRole(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req request.UpdateRoleRequest
+	if !utils.BindAndValidate(c, &req) {
+		return
+	}
+
+	res, err := h.userService.UpdateRole(c.Request.Context(), uint(userID), req)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Failed to update role", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, "Role updated successfully", res)
+}
@@ -15,6 +15,14 @@ func ToUserFromRegister(req request.RegisterRequest, hashedPassword string) *mod
 	}
 }
 
+// ApplyRegisterToGuest mengubah akun guest menjadi user biasa saat guest
+// mendaftar dengan email yang sama; reservation guest ikut ke akun tersebut.
+func ApplyRegisterToGuest(user *model.User, req request.RegisterRequest, hashedPassword string) {
+	user.Name = req.Name
+	user.Password = hashedPassword
+	user.Role = model.RoleUser
+}
+
 func ApplyUpdateProfile(user *model.User, req request.UpdateProfileRequest) {
 	user.Name = req.Name
 }
@@ -33,3 +41,12 @@ func ToAuthResponse(user *model.User, token string) response.AuthResponse {
 		Token: token,
 	}
 }
+
+func ToUserResponse(user *model.User) *response.UserResponse {
+	return &response.UserResponse{
+		ID:    user.ID,
+		Name:  user.Name,
+		Email: user.Email,
+		Role:  user.Role,
+	}
+}
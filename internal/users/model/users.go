@@ -11,12 +11,17 @@ type Role string
 const (
 	RoleAdmin Role = "admin"
 	RoleUser  Role = "user"
+	RoleStaff Role = "staff"
+	// RoleGuest untuk pembeli tanpa akun (guest checkout). Guest tidak punya
+	// password dan tidak bisa login; akses reservation lewat magic link.
+	RoleGuest Role = "guest"
 )
 
 type User struct {
 	gorm.Model
-	Name      string `gorm:"size:255;not null"`
-	Email     string `gorm:"size:255;unique;not null"`
+	Name string `gorm:"size:255;not null"`
+	// Email unik per jenis akun: satu akun terdaftar dan satu akun guest
+	Email     string `gorm:"size:255;not null;index"`
 	Password  string `gorm:"size:255;not null"`
 	Role      Role   `gorm:"type:role;default:'user';index"`
 	LastLogin time.Time
@@ -40,8 +40,10 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.U
 		return nil, fmt.Errorf("email cannot be empty")
 	}
 
+	// email bisa dipakai satu akun terdaftar dan satu akun guest; akun
+	// terdaftar didahulukan
 	var user model.User
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := r.db.WithContext(ctx).Where("email = ?", email).Order("role = 'guest'").First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
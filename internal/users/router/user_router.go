@@ -29,4 +29,11 @@ func UserRoutes(rg *gin.RouterGroup, h *handler.UserHandler, jwtSecret string) {
 		combinedRoutes.PUT("/change-password", h.ChangePassword)
 	}
 
+	// Hanya Admin yang dapat mengubah role user
+	adminRoutes := protected.Group("/")
+	adminRoutes.Use(middleware.RoleBasedAccess(model.RoleAdmin))
+	{
+		adminRoutes.PUT("/:id/role", h.UpdateRole)
+	}
+
 }
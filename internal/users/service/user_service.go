@@ -20,11 +20,17 @@ type UserService interface {
 	Login(ctx context.Context, req request.LoginRequest) (*response.AuthResponse, error)
 	ChangePassword(ctx context.Context, userID uint, req request.ChangePasswordRequest) error
 	UpdateProfile(ctx context.Context, userID uint, req request.UpdateProfileRequest) (*response.AuthResponse, error)
+	UpdateRole(ctx context.Context, userID uint, req request.UpdateRoleRequest) (*response.UserResponse, error)
+	VerifyGuestWith(verifier GuestTokenVerifier)
 }
 
+// GuestTokenVerifier memeriksa apakah token magic link milik akun guest.
+type GuestTokenVerifier func(ctx context.Context, guestID uint, token string) (bool, error)
+
 type userService struct {
-	userRepo  repository.UserRepository
-	jwtSecret string
+	userRepo      repository.UserRepository
+	jwtSecret     string
+	guestVerifier GuestTokenVerifier
 }
 
 func NewUserService(userRepo repository.UserRepository, jwtSecret string) UserService {
@@ -36,7 +42,7 @@ func NewUserService(userRepo repository.UserRepository, jwtSecret string) UserSe
 
 func (s *userService) Register(ctx context.Context, req request.RegisterRequest) (*response.AuthResponse, error) {
 	existingUser, _ := s.userRepo.GetByEmail(ctx, req.Email)
-	if existingUser != nil {
+	if existingUser != nil && existingUser.Role != model.RoleGuest {
 		utils.InfoLogger.Printf("register: email already exists (%s)", req.Email)
 		return nil, fmt.Errorf("email already registered: %s", req.Email)
 	}
@@ -47,6 +53,23 @@ func (s *userService) Register(ctx context.Context, req request.RegisterRequest)
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
+	// email pernah dipakai guest checkout: akun guest hanya dijadikan user biasa
+	// jika pendaftar membuktikan pemilik email dengan token magic link. Tanpa
+	// bukti itu dibuat akun terpisah dan reservation guest tidak ikut berpindah.
+	ownsGuest, err := s.ownsGuest(ctx, existingUser, req.GuestAccessToken)
+	if err != nil {
+		return nil, err
+	}
+	if ownsGuest {
+		mapper.ApplyRegisterToGuest(existingUser, req, string(hashedPassword))
+		if err := s.userRepo.Update(ctx, existingUser); err != nil {
+			utils.ErrorLogger.Printf("register: failed to convert guest (id: %d): %v", existingUser.ID, err)
+			return nil, fmt.Errorf("failed to convert guest account: %w", err)
+		}
+		utils.InfoLogger.Printf("guest converted to user (email: %s)", existingUser.Email)
+		return s.toAuthResponse(existingUser)
+	}
+
 	user := mapper.ToUserFromRegister(req, string(hashedPassword))
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
@@ -58,9 +81,25 @@ func (s *userService) Register(ctx context.Context, req request.RegisterRequest)
 	return s.toAuthResponse(user)
 }
 
+func (s *userService) VerifyGuestWith(verifier GuestTokenVerifier) {
+	s.guestVerifier = verifier
+}
+
+func (s *userService) ownsGuest(ctx context.Context, guest *model.User, token string) (bool, error) {
+	if guest == nil || token == "" || s.guestVerifier == nil {
+		return false, nil
+	}
+	ok, err := s.guestVerifier(ctx, guest.ID, token)
+	if err != nil {
+		utils.ErrorLogger.Printf("register: failed to verify guest token (id: %d): %v", guest.ID, err)
+		return false, fmt.Errorf("failed to verify guest access token: %w", err)
+	}
+	return ok, nil
+}
+
 func (s *userService) Login(ctx context.Context, req request.LoginRequest) (*response.AuthResponse, error) {
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
-	if err != nil || user == nil {
+	if err != nil || user == nil || user.Role == model.RoleGuest {
 		utils.InfoLogger.Printf("login: invalid credentials (email: %s)", req.Email)
 		return nil, fmt.Errorf("invalid credentials: %s", req.Email)
 	}
@@ -119,6 +158,28 @@ func (s *userService) ChangePassword(ctx context.Context, userID uint, req reque
 	return nil
 }
 
+// UpdateRole dipakai admin untuk memberi role, misalnya staff untuk petugas check-in.
+// Role baru berlaku setelah user login ulang.
+func (s *userService) UpdateRole(ctx context.Context, userID uint, req request.UpdateRoleRequest) (*response.UserResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		utils.ErrorLogger.Printf("update role: failed to get user (id: %d): %v", userID, err)
+		return nil, fmt.Errorf("failed to get user with ID %d: %w", userID, err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user with ID %d not found", userID)
+	}
+
+	user.Role = model.Role(req.Role)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		utils.ErrorLogger.Printf("update role: failed to update user (id: %d): %v", user.ID, err)
+		return nil, fmt.Errorf("failed to update user with ID %d: %w", user.ID, err)
+	}
+
+	utils.InfoLogger.Printf("role updated (id: %d, role: %s)", user.ID, user.Role)
+	return mapper.ToUserResponse(user), nil
+}
+
 func (s *userService) generateToken(user *model.User) (string, error) {
 	claims := jwt.MapClaims{
 		"sub":   user.ID,
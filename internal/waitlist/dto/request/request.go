@@ -0,0 +1,6 @@
+package request
+
+type JoinWaitlistRequest struct {
+	ShowtimeID uint `json:"showtime_id" binding:"required"`
+	SeatCount  int  `json:"seat_count" binding:"required,min=1"`
+}
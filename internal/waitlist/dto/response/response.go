@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type WaitlistResponse struct {
+	ID             uint       `json:"id"`
+	UserID         uint       `json:"user_id"`
+	ShowtimeID     uint       `json:"showtime_id"`
+	SeatCount      int        `json:"seat_count"`
+	Status         string     `json:"status"`
+	Position       int        `json:"position,omitempty"`
+	ReservationID  *uint      `json:"reservation_id,omitempty"`
+	OfferedAt      *time.Time `json:"offered_at,omitempty"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/service"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WaitlistHandler struct {
+	service service.WaitlistService
+}
+
+func NewWaitlistHandler(service service.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{service: service}
+}
+
+func (h *WaitlistHandler) JoinWaitlist(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	var req request.JoinWaitlistRequest
+	if !utils.BindAndValidate(c, &req) {
+		return
+	}
+
+	res, err := h.service.Join(c.Request.Context(), user.ID, req)
+	if err != nil {
+		respondWaitlistError(c, "Failed to join waitlist", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Joined waitlist successfully", res)
+}
+
+func (h *WaitlistHandler) GetMyEntries(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.service.GetMyEntries(c.Request.Context(), user.ID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch waitlist", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Waitlist fetched successfully", res)
+}
+
+func (h *WaitlistHandler) GetShowtimeWaitlist(c *gin.Context) {
+	showtimeID, err := strconv.ParseUint(c.Param("showtime_id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid showtime ID", err)
+		return
+	}
+
+	res, err := h.service.GetShowtimeWaitlist(c.Request.Context(), uint(showtimeID))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch waitlist", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Waitlist fetched successfully", res)
+}
+
+func (h *WaitlistHandler) LeaveWaitlist(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid waitlist ID", err)
+		return
+	}
+
+	if err := h.service.Leave(c.Request.Context(), user, uint(id)); err != nil {
+		respondWaitlistError(c, "Failed to leave waitlist", err)
+		return
+	}
+	utils.RespondWithSuccess(c, "Left waitlist successfully", nil)
+}
+
+func respondWaitlistError(c *gin.Context, msg string, err error) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		utils.RespondWithError(c, http.StatusForbidden, msg, err)
+	case errors.Is(err, service.ErrAlreadyWaitlisted), errors.Is(err, service.ErrSeatsAvailable),
+		errors.Is(err, service.ErrShowtimeStarted), errors.Is(err, showtimeModel.ErrShowtimeCancelled), errors.Is(err, service.ErrNotWaiting):
+		utils.RespondWithError(c, http.StatusConflict, msg, err)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, "Not found", err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, msg, err)
+	}
+}
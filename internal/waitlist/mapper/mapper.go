@@ -0,0 +1,21 @@
+package mapper
+
+import (
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/model"
+)
+
+func ToWaitlistResponse(e *model.WaitlistEntry, position int) *response.WaitlistResponse {
+	return &response.WaitlistResponse{
+		ID:             e.ID,
+		UserID:         e.UserID,
+		ShowtimeID:     e.ShowtimeID,
+		SeatCount:      e.SeatCount,
+		Status:         e.Status,
+		Position:       position,
+		ReservationID:  e.ReservationID,
+		OfferedAt:      e.OfferedAt,
+		OfferExpiresAt: e.OfferExpiresAt,
+		CreatedAt:      e.CreatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+const (
+	StatusWaiting   = "waiting"
+	StatusOffered   = "offered"
+	StatusFulfilled = "fulfilled"
+	StatusExpired   = "expired"
+	StatusCancelled = "cancelled"
+)
+
+// WaitlistEntry adalah antrean user untuk showtime yang sudah penuh. Saat seat
+// dilepas, entry paling awal yang muat mendapat hold (reservation pending).
+type WaitlistEntry struct {
+	ID             uint   `gorm:"primaryKey"`
+	UserID         uint   `gorm:"not null;index"`
+	ShowtimeID     uint   `gorm:"not null;index:idx_waitlist_showtime_status"`
+	SeatCount      int    `gorm:"not null"`
+	Status         string `gorm:"type:varchar(20);not null;default:'waiting';index:idx_waitlist_showtime_status"`
+	ReservationID  *uint  `gorm:"index"`
+	OfferedAt      *time.Time
+	OfferExpiresAt *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
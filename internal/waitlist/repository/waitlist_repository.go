@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/model"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WaitlistRepository interface {
+	Create(ctx context.Context, entry *model.WaitlistEntry) error
+	GetByID(ctx context.Context, id uint) (*model.WaitlistEntry, error)
+	GetByUser(ctx context.Context, userID uint) ([]model.WaitlistEntry, error)
+	GetByShowtime(ctx context.Context, showtimeID uint) ([]model.WaitlistEntry, error)
+	GetWaiting(ctx context.Context, showtimeID uint) ([]model.WaitlistEntry, error)
+	HasActiveEntry(ctx context.Context, userID, showtimeID uint) (bool, error)
+	CountAhead(ctx context.Context, entry *model.WaitlistEntry) (int64, error)
+	MarkOffered(ctx context.Context, id, reservationID uint, offeredAt, expiresAt time.Time) (bool, error)
+	UpdateStatus(ctx context.Context, id uint, from, to string) (bool, error)
+	ResolveOffers(ctx context.Context, showtimeID uint) error
+	ExpireWaiting(ctx context.Context, showtimeID uint) error
+	WithShowtimeLock(ctx context.Context, showtimeID uint, fn func() error) error
+}
+
+type waitlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+func (r *waitlistRepository) Create(ctx context.Context, entry *model.WaitlistEntry) error {
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to create waitlist entry: %v", err)
+		return fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+	return nil
+}
+
+func (r *waitlistRepository) GetByID(ctx context.Context, id uint) (*model.WaitlistEntry, error) {
+	var entry model.WaitlistEntry
+	if err := r.db.WithContext(ctx).First(&entry, id).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to get waitlist entry (ID: %d): %v", id, err)
+		return nil, fmt.Errorf("failed to get waitlist entry: %w", err)
+	}
+	return &entry, nil
+}
+
+func (r *waitlistRepository) GetByUser(ctx context.Context, userID uint) ([]model.WaitlistEntry, error) {
+	var entries []model.WaitlistEntry
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&entries).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to get waitlist entries (user: %d): %v", userID, err)
+		return nil, fmt.Errorf("failed to get waitlist entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *waitlistRepository) GetByShowtime(ctx context.Context, showtimeID uint) ([]model.WaitlistEntry, error) {
+	var entries []model.WaitlistEntry
+	if err := r.db.WithContext(ctx).Where("showtime_id = ?", showtimeID).Order("created_at, id").Find(&entries).Error; err != nil {
+		utils.ErrorLogger.Printf("Error to get waitlist entries (showtime: %d): %v", showtimeID, err)
+		return nil, fmt.Errorf("failed to get waitlist entries: %w", err)
+	}
+	return entries, nil
+}
+
+// GetWaiting mengembalikan entry yang masih menunggu, urut FIFO.
+func (r *waitlistRepository) GetWaiting(ctx context.Context, showtimeID uint) ([]model.WaitlistEntry, error) {
+	var entries []model.WaitlistEntry
+	err := r.db.WithContext(ctx).
+		Where("showtime_id = ? AND status = ?", showtimeID, model.StatusWaiting).
+		Order("created_at, id").
+		Find(&entries).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to get waiting entries (showtime: %d): %v", showtimeID, err)
+		return nil, fmt.Errorf("failed to get waiting entries: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *waitlistRepository) HasActiveEntry(ctx context.Context, userID, showtimeID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.WaitlistEntry{}).
+		Where("user_id = ? AND showtime_id = ? AND status IN ?", userID, showtimeID, []string{model.StatusWaiting, model.StatusOffered}).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to check waitlist entry: %w", err)
+	}
+	return count > 0, nil
+}
+
+// CountAhead menghitung entry menunggu yang berada di depan entry ini.
+func (r *waitlistRepository) CountAhead(ctx context.Context, entry *model.WaitlistEntry) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&model.WaitlistEntry{}).
+		Where("showtime_id = ? AND status = ?", entry.ShowtimeID, model.StatusWaiting).
+		Where("(created_at, id) < (?, ?)", entry.CreatedAt, entry.ID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count waitlist position: %w", err)
+	}
+	return count, nil
+}
+
+// MarkOffered hanya berhasil jika entry masih waiting, supaya satu entry tidak
+// mendapat dua hold.
+func (r *waitlistRepository) MarkOffered(ctx context.Context, id, reservationID uint, offeredAt, expiresAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.WaitlistEntry{}).
+		Where("id = ? AND status = ?", id, model.StatusWaiting).
+		Updates(map[string]any{
+			"status":           model.StatusOffered,
+			"reservation_id":   reservationID,
+			"offered_at":       offeredAt,
+			"offer_expires_at": expiresAt,
+		})
+	if result.Error != nil {
+		utils.ErrorLogger.Printf("Error to mark waitlist entry offered (ID: %d): %v", id, result.Error)
+		return false, fmt.Errorf("failed to update waitlist entry: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *waitlistRepository) UpdateStatus(ctx context.Context, id uint, from, to string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.WaitlistEntry{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	if result.Error != nil {
+		utils.ErrorLogger.Printf("Error to update waitlist entry (ID: %d): %v", id, result.Error)
+		return false, fmt.Errorf("failed to update waitlist entry: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ResolveOffers menyelaraskan entry offered dengan status reservation hold-nya:
+// dikonfirmasi berarti fulfilled, expired/dibatalkan berarti tawaran hangus.
+func (r *waitlistRepository) ResolveOffers(ctx context.Context, showtimeID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		resolve := func(to string, reservationStatuses []string) error {
+			return tx.Model(&model.WaitlistEntry{}).
+				Where("showtime_id = ? AND status = ?", showtimeID, model.StatusOffered).
+				Where("reservation_id IN (?)", tx.Model(&reservationModel.Reservation{}).Select("id").Where("status IN ?", reservationStatuses)).
+				Update("status", to).Error
+		}
+		if err := resolve(model.StatusFulfilled, []string{reservationModel.StatusConfirmed, reservationModel.StatusCheckedIn}); err != nil {
+			return fmt.Errorf("failed to resolve fulfilled offers: %w", err)
+		}
+		if err := resolve(model.StatusExpired, []string{reservationModel.StatusExpired, reservationModel.StatusCancelled, reservationModel.StatusRefunded}); err != nil {
+			return fmt.Errorf("failed to resolve expired offers: %w", err)
+		}
+		return nil
+	})
+}
+
+// ExpireWaiting menutup semua entry yang masih menunggu, misalnya saat showtime sudah mulai.
+func (r *waitlistRepository) ExpireWaiting(ctx context.Context, showtimeID uint) error {
+	err := r.db.WithContext(ctx).Model(&model.WaitlistEntry{}).
+		Where("showtime_id = ? AND status = ?", showtimeID, model.StatusWaiting).
+		Update("status", model.StatusExpired).Error
+	if err != nil {
+		utils.ErrorLogger.Printf("Error to expire waitlist (showtime: %d): %v", showtimeID, err)
+		return fmt.Errorf("failed to expire waitlist: %w", err)
+	}
+	return nil
+}
+
+// WithShowtimeLock menjalankan fn sambil memegang row lock showtime, sehingga
+// promosi waitlist satu showtime tidak berjalan paralel, juga antar instance.
+// fn memakai koneksinya sendiri; lock hanya menjadi penanda giliran. Lock
+// NO KEY UPDATE dipakai agar insert reservation (FK ke showtimes) di dalam fn
+// tidak ikut tertahan.
+func (r *waitlistRepository) WithShowtimeLock(ctx context.Context, showtimeID uint, fn func() error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "NO KEY UPDATE"}).
+			Select("id").
+			Take(&showtimeModel.Showtime{}, showtimeID).Error
+		if err != nil {
+			utils.ErrorLogger.Printf("Error to lock showtime for waitlist (showtime: %d): %v", showtimeID, err)
+			return fmt.Errorf("failed to lock showtime: %w", err)
+		}
+		return fn()
+	})
+}
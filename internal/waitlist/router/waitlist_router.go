@@ -0,0 +1,30 @@
+package router
+
+import (
+	"github.com/didanslmn/movie-reservation-system.git/internal/middleware"
+	"github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/handler"
+	"github.com/gin-gonic/gin"
+)
+
+func WaitlistRoutes(rg *gin.RouterGroup, h *handler.WaitlistHandler, jwtSecret string) {
+	waitlist := rg.Group("/waitlist")
+
+	// User & Admin dapat masuk, melihat, dan keluar dari waitlist
+	publicRoutes := waitlist.Group("/")
+	publicRoutes.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	publicRoutes.Use(middleware.RoleBasedAccess(model.RoleUser, model.RoleAdmin))
+	{
+		publicRoutes.POST("/", h.JoinWaitlist)
+		publicRoutes.GET("/", h.GetMyEntries)
+		publicRoutes.DELETE("/:id", h.LeaveWaitlist)
+	}
+
+	// Hanya Admin yang dapat melihat antrean satu showtime
+	adminRoutes := waitlist.Group("/")
+	adminRoutes.Use(middleware.JWTAuthMiddleware(jwtSecret))
+	adminRoutes.Use(middleware.RoleBasedAccess(model.RoleAdmin))
+	{
+		adminRoutes.GET("/showtimes/:showtime_id", h.GetShowtimeWaitlist)
+	}
+}
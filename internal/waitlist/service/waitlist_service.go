@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	reservationModel "github.com/didanslmn/movie-reservation-system.git/internal/reservation/model"
+	reservationRepository "github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
+	reservationService "github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	seatModel "github.com/didanslmn/movie-reservation-system.git/internal/seat/model"
+	seatRepository "github.com/didanslmn/movie-reservation-system.git/internal/seat/repository"
+	showtimeModel "github.com/didanslmn/movie-reservation-system.git/internal/showtime/model"
+	showtimeRepository "github.com/didanslmn/movie-reservation-system.git/internal/showtime/repository"
+	userModel "github.com/didanslmn/movie-reservation-system.git/internal/users/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/dto/request"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/dto/response"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/mapper"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/model"
+	"github.com/didanslmn/movie-reservation-system.git/internal/waitlist/repository"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
+)
+
+var (
+	ErrForbidden         = errors.New("waitlist entry belongs to another user")
+	ErrAlreadyWaitlisted = errors.New("already on the waitlist for this showtime")
+	ErrSeatsAvailable    = errors.New("showtime still has enough seats, reserve them directly")
+	ErrShowtimeStarted   = errors.New("showtime already started")
+	ErrNotWaiting        = errors.New("only waiting entries can be cancelled")
+)
+
+type WaitlistService interface {
+	Join(ctx context.Context, userID uint, req request.JoinWaitlistRequest) (*response.WaitlistResponse, error)
+	GetMyEntries(ctx context.Context, userID uint) ([]response.WaitlistResponse, error)
+	GetShowtimeWaitlist(ctx context.Context, showtimeID uint) ([]response.WaitlistResponse, error)
+	Leave(ctx context.Context, actor *userModel.User, id uint) error
+	Promote(ctx context.Context, showtimeID uint)
+	OnOffered(listener OfferListener)
+}
+
+// OfferListener dipanggil (di goroutine terpisah) setelah entry waitlist
+// mendapat hold seat, misalnya untuk mengirim email ke user.
+type OfferListener func(ctx context.Context, r *reservationModel.Reservation)
+
+type waitlistService struct {
+	waitlistRepo    repository.WaitlistRepository
+	showtimeRepo    showtimeRepository.ShowtimeRepository
+	seatRepo        seatRepository.SeatRepository
+	reservationSvc  reservationService.ReservationService
+	reservationRepo reservationRepository.ReservationRepository
+
+	offerListeners []OfferListener
+}
+
+func NewWaitlistService(
+	waitlistRepo repository.WaitlistRepository,
+	showtimeRepo showtimeRepository.ShowtimeRepository,
+	seatRepo seatRepository.SeatRepository,
+	reservationSvc reservationService.ReservationService,
+	reservationRepo reservationRepository.ReservationRepository,
+) WaitlistService {
+	return &waitlistService{
+		waitlistRepo:    waitlistRepo,
+		showtimeRepo:    showtimeRepo,
+		seatRepo:        seatRepo,
+		reservationSvc:  reservationSvc,
+		reservationRepo: reservationRepo,
+	}
+}
+
+func (s *waitlistService) Join(ctx context.Context, userID uint, req request.JoinWaitlistRequest) (*response.WaitlistResponse, error) {
+	showtime, err := s.showtimeRepo.GetByID(ctx, req.ShowtimeID)
+	if err != nil {
+		return nil, err
+	}
+	if showtime.Status == showtimeModel.StatusCancelled {
+		return nil, showtimeModel.ErrShowtimeCancelled
+	}
+	if !showtime.StartTime.After(time.Now()) {
+		return nil, ErrShowtimeStarted
+	}
+
+	exists, err := s.waitlistRepo.HasActiveEntry(ctx, userID, req.ShowtimeID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrAlreadyWaitlisted
+	}
+
+	available, err := s.seatRepo.GetAvailableSeats(ctx, req.ShowtimeID)
+	if err != nil {
+		return nil, err
+	}
+	// seat_count adalah jumlah orang, couple seat dihitung sesuai kapasitasnya
+	if seatModel.TotalCapacity(available) >= req.SeatCount {
+		return nil, ErrSeatsAvailable
+	}
+
+	entry := &model.WaitlistEntry{
+		UserID:     userID,
+		ShowtimeID: req.ShowtimeID,
+		SeatCount:  req.SeatCount,
+		Status:     model.StatusWaiting,
+	}
+	if err := s.waitlistRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	utils.InfoLogger.Printf("User %d joined waitlist for showtime %d (%d seats)", userID, req.ShowtimeID, req.SeatCount)
+	return s.toResponse(ctx, entry)
+}
+
+func (s *waitlistService) GetMyEntries(ctx context.Context, userID uint) ([]response.WaitlistResponse, error) {
+	entries, err := s.waitlistRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponseList(ctx, entries)
+}
+
+func (s *waitlistService) GetShowtimeWaitlist(ctx context.Context, showtimeID uint) ([]response.WaitlistResponse, error) {
+	entries, err := s.waitlistRepo.GetByShowtime(ctx, showtimeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponseList(ctx, entries)
+}
+
+func (s *waitlistService) Leave(ctx context.Context, actor *userModel.User, id uint) error {
+	entry, err := s.waitlistRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if actor.Role != userModel.RoleAdmin && entry.UserID != actor.ID {
+		return ErrForbidden
+	}
+
+	// entry yang sudah ditawari dibatalkan lewat reservation hold-nya. Lock
+	// showtime mencegah entry dibatalkan saat promosi sedang menahan seat untuknya.
+	var ok bool
+	err = s.waitlistRepo.WithShowtimeLock(ctx, entry.ShowtimeID, func() error {
+		ok, err = s.waitlistRepo.UpdateStatus(ctx, id, model.StatusWaiting, model.StatusCancelled)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotWaiting
+	}
+	utils.InfoLogger.Printf("Waitlist entry cancelled (ID: %d)", id)
+	return nil
+}
+
+// Promote dipanggil saat seat showtime dilepas. Entry diproses FIFO; entry yang
+// butuh seat lebih banyak dari yang tersedia dilewati tanpa kehilangan posisi.
+// Promosi satu showtime diproses bergiliran lewat row lock showtime supaya
+// seat yang sama tidak ditawarkan dua kali.
+func (s *waitlistService) Promote(ctx context.Context, showtimeID uint) {
+	err := s.waitlistRepo.WithShowtimeLock(ctx, showtimeID, func() error {
+		s.promote(ctx, showtimeID)
+		return nil
+	})
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to promote waitlist (showtime: %d): %v", showtimeID, err)
+	}
+}
+
+func (s *waitlistService) promote(ctx context.Context, showtimeID uint) {
+	if err := s.waitlistRepo.ResolveOffers(ctx, showtimeID); err != nil {
+		utils.ErrorLogger.Printf("Failed to resolve waitlist offers (showtime: %d): %v", showtimeID, err)
+		return
+	}
+
+	showtime, err := s.showtimeRepo.GetByID(ctx, showtimeID)
+	if err != nil {
+		return
+	}
+	if showtime.Status == showtimeModel.StatusCancelled || !showtime.StartTime.After(time.Now()) {
+		_ = s.waitlistRepo.ExpireWaiting(ctx, showtimeID)
+		return
+	}
+
+	entries, err := s.waitlistRepo.GetWaiting(ctx, showtimeID)
+	if err != nil || len(entries) == 0 {
+		return
+	}
+	available, err := s.seatRepo.GetAvailableSeats(ctx, showtimeID)
+	if err != nil {
+		return
+	}
+
+	remaining := seatModel.TotalCapacity(available)
+	for _, entry := range entries {
+		if remaining == 0 {
+			break
+		}
+		if entry.SeatCount > remaining {
+			continue
+		}
+
+		hold, err := s.reservationSvc.HoldSeats(ctx, entry.UserID, showtimeID, entry.SeatCount)
+		if errors.Is(err, reservationService.ErrNotEnoughSeats) {
+			// seat sudah diambil pembeli lain, cek ulang sisanya
+			if available, err = s.seatRepo.GetAvailableSeats(ctx, showtimeID); err != nil {
+				return
+			}
+			remaining = seatModel.TotalCapacity(available)
+			continue
+		}
+		if err != nil {
+			// misalnya batas booking user, entry tetap menunggu
+			utils.ErrorLogger.Printf("Failed to hold seats for waitlist entry %d: %v", entry.ID, err)
+			continue
+		}
+
+		if _, err := s.waitlistRepo.MarkOffered(ctx, entry.ID, hold.ID, time.Now(), hold.ExpiredAt); err != nil {
+			// hold tetap milik user dan akan expire sendiri jika tidak dikonfirmasi
+			utils.ErrorLogger.Printf("Failed to record waitlist offer (entry: %d, reservation: %d): %v", entry.ID, hold.ID, err)
+		}
+
+		remaining -= entry.SeatCount
+		s.notifyOffer(ctx, &entry, hold.ID)
+	}
+}
+
+func (s *waitlistService) OnOffered(listener OfferListener) {
+	s.offerListeners = append(s.offerListeners, listener)
+}
+
+func (s *waitlistService) notifyOffer(ctx context.Context, entry *model.WaitlistEntry, reservationID uint) {
+	utils.InfoLogger.Printf("Waitlist offer for user %d: %d seat(s) held on showtime %d as reservation %d",
+		entry.UserID, entry.SeatCount, entry.ShowtimeID, reservationID)
+	if len(s.offerListeners) == 0 {
+		return
+	}
+	r, err := s.reservationRepo.GetByID(ctx, reservationID)
+	if err != nil {
+		utils.ErrorLogger.Printf("Failed to load waitlist offer (reservation: %d): %v", reservationID, err)
+		return
+	}
+	for _, listener := range s.offerListeners {
+		go listener(context.Background(), r)
+	}
+}
+
+func (s *waitlistService) toResponse(ctx context.Context, entry *model.WaitlistEntry) (*response.WaitlistResponse, error) {
+	position := 0
+	if entry.Status == model.StatusWaiting {
+		ahead, err := s.waitlistRepo.CountAhead(ctx, entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get waitlist position: %w", err)
+		}
+		position = int(ahead) + 1
+	}
+	return mapper.ToWaitlistResponse(entry, position), nil
+}
+
+func (s *waitlistService) toResponseList(ctx context.Context, entries []model.WaitlistEntry) ([]response.WaitlistResponse, error) {
+	res := make([]response.WaitlistResponse, 0, len(entries))
+	for i := range entries {
+		r, err := s.toResponse(ctx, &entries[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *r)
+	}
+	return res, nil
+}
@@ -0,0 +1,27 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS showtime_seats (
+    id SERIAL PRIMARY KEY,
+    showtime_id INTEGER NOT NULL REFERENCES showtimes(id) ON DELETE CASCADE,
+    seat_id INTEGER NOT NULL REFERENCES seats(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'available',
+    reservation_id INTEGER REFERENCES reservations(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+
+    CONSTRAINT idx_showtime_seats_showtime_seat UNIQUE (showtime_id, seat_id)
+);
+CREATE INDEX idx_showtime_seats_reservation_id ON showtime_seats(reservation_id);
+
+-- inventori untuk showtime yang sudah ada
+INSERT INTO showtime_seats (showtime_id, seat_id, status)
+SELECT sh.id, s.id, 'available'
+FROM showtimes sh
+JOIN seats s ON s.cinema_hall_id = sh.cinema_hall_id AND s.deleted_at IS NULL
+WHERE sh.deleted_at IS NULL;
+
+UPDATE showtime_seats ss
+SET status = 'booked', reservation_id = r.id
+FROM reservation_seats rs
+JOIN reservations r ON r.id = rs.reservation_id AND r.deleted_at IS NULL
+WHERE ss.seat_id = rs.seat_id AND ss.showtime_id = r.showtime_id;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+DROP INDEX IF EXISTS idx_reservations_status_expired_at;
+COMMIT;
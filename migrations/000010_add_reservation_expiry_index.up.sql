@@ -0,0 +1,3 @@
+BEGIN;
+CREATE INDEX IF NOT EXISTS idx_reservations_status_expired_at ON reservations(status, expired_at);
+COMMIT;
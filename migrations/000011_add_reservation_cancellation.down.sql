@@ -0,0 +1,6 @@
+BEGIN;
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS cancelled_by,
+    DROP COLUMN IF EXISTS cancel_reason;
+COMMIT;
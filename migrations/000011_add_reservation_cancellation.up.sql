@@ -0,0 +1,6 @@
+BEGIN;
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS cancelled_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS cancel_reason VARCHAR(255);
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    reservation_id INTEGER REFERENCES reservations(id) ON DELETE SET NULL,
+    response JSONB,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+
+    CONSTRAINT idx_idempotency_keys_user_key UNIQUE (user_id, key)
+);
+COMMIT;
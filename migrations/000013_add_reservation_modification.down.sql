@@ -0,0 +1,6 @@
+BEGIN;
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS modified_at,
+    DROP COLUMN IF EXISTS modification_count,
+    DROP COLUMN IF EXISTS modification_note;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS modified_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS modification_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS modification_note VARCHAR(255);
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS booking_limits (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    max_seats_per_reservation INTEGER NOT NULL DEFAULT 0,
+    max_seats_per_showtime INTEGER NOT NULL DEFAULT 0,
+    max_active_reservations INTEGER NOT NULL DEFAULT 0,
+    note VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    showtime_id INTEGER NOT NULL REFERENCES showtimes(id) ON DELETE CASCADE,
+    seat_count INTEGER NOT NULL CHECK (seat_count > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    reservation_id INTEGER REFERENCES reservations(id) ON DELETE SET NULL,
+    offered_at TIMESTAMP,
+    offer_expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_waitlist_showtime_status ON waitlist_entries (showtime_id, status);
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_user_id ON waitlist_entries (user_id);
+CREATE INDEX IF NOT EXISTS idx_waitlist_entries_reservation_id ON waitlist_entries (reservation_id);
+
+-- satu entry aktif per user per showtime
+CREATE UNIQUE INDEX IF NOT EXISTS idx_waitlist_active_user_showtime
+    ON waitlist_entries (user_id, showtime_id)
+    WHERE status IN ('waiting', 'offered');
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE cinema_halls DROP COLUMN IF EXISTS seat_gap_policy;
+COMMIT;
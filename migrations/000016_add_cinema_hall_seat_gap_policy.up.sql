@@ -0,0 +1,5 @@
+BEGIN;
+ALTER TABLE cinema_halls
+    ADD COLUMN IF NOT EXISTS seat_gap_policy VARCHAR(10) NOT NULL DEFAULT 'warn'
+    CHECK (seat_gap_policy IN ('off', 'warn', 'reject'));
+COMMIT;
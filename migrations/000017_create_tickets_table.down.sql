@@ -0,0 +1,5 @@
+BEGIN;
+DROP TABLE IF EXISTS tickets;
+-- nilai enum tidak bisa dihapus, user staff dikembalikan menjadi user biasa
+UPDATE users SET role = 'user' WHERE role::text = 'staff';
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS tickets (
+    id SERIAL PRIMARY KEY,
+    reservation_id INTEGER NOT NULL UNIQUE REFERENCES reservations(id) ON DELETE CASCADE,
+    nonce VARCHAR(64) NOT NULL,
+    issued_at TIMESTAMP NOT NULL DEFAULT now(),
+    used_at TIMESTAMP,
+    used_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS reservation_events (
+    id SERIAL PRIMARY KEY,
+    reservation_id INTEGER NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+    type VARCHAR(30) NOT NULL,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    actor_role VARCHAR(20),
+    from_status VARCHAR(20),
+    to_status VARCHAR(20),
+    old_value TEXT,
+    new_value TEXT,
+    reason VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_events_reservation_id ON reservation_events (reservation_id, created_at);
+COMMIT;
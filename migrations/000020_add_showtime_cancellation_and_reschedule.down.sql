@@ -0,0 +1,10 @@
+BEGIN;
+ALTER TABLE reservations
+    DROP COLUMN IF EXISTS rescheduled_from,
+    DROP COLUMN IF EXISTS reschedule_status;
+
+ALTER TABLE showtimes
+    DROP COLUMN IF EXISTS cancel_reason,
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS status;
+COMMIT;
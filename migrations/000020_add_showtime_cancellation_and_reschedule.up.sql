@@ -0,0 +1,11 @@
+BEGIN;
+ALTER TABLE showtimes
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'scheduled'
+    CHECK (status IN ('scheduled', 'cancelled')),
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS cancel_reason VARCHAR(255);
+
+ALTER TABLE reservations
+    ADD COLUMN IF NOT EXISTS reschedule_status VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS rescheduled_from TIMESTAMP;
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS reservation_transfers (
+    id SERIAL PRIMARY KEY,
+    reservation_id INTEGER NOT NULL REFERENCES reservations(id) ON DELETE CASCADE,
+    from_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    seat_ids TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    new_reservation_id INTEGER REFERENCES reservations(id) ON DELETE SET NULL,
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reservation_transfers_reservation_id ON reservation_transfers (reservation_id);
+CREATE INDEX IF NOT EXISTS idx_reservation_transfers_from_user_id ON reservation_transfers (from_user_id);
+CREATE INDEX IF NOT EXISTS idx_reservation_transfers_to_user_id ON reservation_transfers (to_user_id);
+-- hanya satu transfer pending per reservation
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reservation_transfers_pending ON reservation_transfers (reservation_id) WHERE status = 'pending';
+COMMIT;
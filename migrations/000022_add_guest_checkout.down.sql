@@ -0,0 +1,5 @@
+BEGIN;
+DROP TABLE IF EXISTS guest_access_tokens;
+-- nilai enum tidak bisa dihapus; akun guest tanpa password tetap tidak bisa login
+UPDATE users SET role = 'user' WHERE role::text = 'guest';
+COMMIT;
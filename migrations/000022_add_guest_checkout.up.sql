@@ -0,0 +1,8 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS guest_access_tokens (
+    id SERIAL PRIMARY KEY,
+    reservation_id INTEGER NOT NULL UNIQUE REFERENCES reservations(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+DROP TABLE IF EXISTS price_rules;
+ALTER TABLE reservation_seats
+    DROP COLUMN IF EXISTS price_breakdown,
+    DROP COLUMN IF EXISTS price,
+    DROP COLUMN IF EXISTS ticket_type;
+ALTER TABLE reservations DROP COLUMN IF EXISTS total_price;
+ALTER TABLE showtimes DROP COLUMN IF EXISTS base_price;
+COMMIT;
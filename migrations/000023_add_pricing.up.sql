@@ -0,0 +1,25 @@
+BEGIN;
+ALTER TABLE showtimes ADD COLUMN IF NOT EXISTS base_price BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS total_price BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE reservation_seats
+    ADD COLUMN IF NOT EXISTS ticket_type VARCHAR(20) NOT NULL DEFAULT 'adult',
+    ADD COLUMN IF NOT EXISTS price BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS price_breakdown TEXT;
+
+CREATE TABLE IF NOT EXISTS price_rules (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    seat_category VARCHAR(30),
+    ticket_type VARCHAR(20),
+    days VARCHAR(40),
+    start_time VARCHAR(5),
+    end_time VARCHAR(5),
+    percent INTEGER NOT NULL DEFAULT 0,
+    amount BIGINT NOT NULL DEFAULT 0,
+    priority INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+COMMIT;
@@ -0,0 +1,4 @@
+BEGIN;
+ALTER TABLE seats DROP COLUMN IF EXISTS category;
+DROP TABLE IF EXISTS seat_categories;
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+CREATE TABLE IF NOT EXISTS seat_categories (
+    name VARCHAR(30) PRIMARY KEY,
+    description VARCHAR(255),
+    price_percent INTEGER NOT NULL DEFAULT 0,
+    price_amount BIGINT NOT NULL DEFAULT 0,
+    capacity INTEGER NOT NULL DEFAULT 1 CHECK (capacity > 0),
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+INSERT INTO seat_categories (name, description, price_percent, capacity) VALUES
+    ('standard', 'Seat reguler', 0, 1),
+    ('premium', 'Seat premium dengan posisi terbaik', 25, 1),
+    ('couple', 'Couple seat untuk dua orang', 100, 2),
+    ('wheelchair', 'Area kursi roda', 0, 1)
+ON CONFLICT (name) DO NOTHING;
+
+-- kategori yang masih dipakai seat (termasuk seat yang di-soft delete) tidak
+-- bisa dihapus; perubahan nama kategori ikut ke seat
+ALTER TABLE seats ADD COLUMN IF NOT EXISTS category VARCHAR(30) NOT NULL DEFAULT 'standard'
+    REFERENCES seat_categories (name) ON UPDATE CASCADE ON DELETE RESTRICT;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE reservations DROP COLUMN IF EXISTS confirmed_at;
+COMMIT;
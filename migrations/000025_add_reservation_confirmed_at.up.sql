@@ -0,0 +1,20 @@
+BEGIN;
+ALTER TABLE reservations ADD COLUMN IF NOT EXISTS confirmed_at TIMESTAMP;
+
+-- isi dari audit trail; reservation hasil split transfer tidak punya event
+-- confirm sehingga diisi dari status yang hanya bisa dicapai setelah confirm
+UPDATE reservations r
+SET confirmed_at = e.confirmed_at
+FROM (
+    SELECT reservation_id, MIN(created_at) AS confirmed_at
+    FROM reservation_events
+    WHERE type = 'confirm'
+    GROUP BY reservation_id
+) e
+WHERE e.reservation_id = r.id;
+
+UPDATE reservations
+SET confirmed_at = updated_at
+WHERE confirmed_at IS NULL
+  AND status IN ('confirmed', 'checked_in', 'completed', 'no_show', 'refunded');
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+ALTER TABLE reservations ALTER COLUMN modification_note TYPE VARCHAR(255) USING LEFT(modification_note, 255);
+COMMIT;
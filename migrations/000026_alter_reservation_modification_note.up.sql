@@ -0,0 +1,4 @@
+BEGIN;
+-- catatan perubahan memuat daftar seat lama dan baru sehingga bisa melebihi 255 karakter
+ALTER TABLE reservations ALTER COLUMN modification_note TYPE TEXT;
+COMMIT;
@@ -0,0 +1 @@
+-- nilai enum tidak bisa dihapus; akun guest dikembalikan oleh down migration 000022
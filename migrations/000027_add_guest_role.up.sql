@@ -0,0 +1,3 @@
+-- tanpa BEGIN/COMMIT: ALTER TYPE ... ADD VALUE tidak boleh dipakai bersama
+-- nilai barunya di transaksi yang sama, jadi dipisah dari migration lain
+ALTER TYPE role ADD VALUE IF NOT EXISTS 'guest';
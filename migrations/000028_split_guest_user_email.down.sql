@@ -0,0 +1,5 @@
+BEGIN;
+-- gagal jika sudah ada email yang dipakai akun guest dan akun terdaftar
+DROP INDEX IF EXISTS idx_users_email_guest;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+COMMIT;
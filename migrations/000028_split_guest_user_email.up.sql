@@ -0,0 +1,6 @@
+BEGIN;
+-- akun guest tidak lagi memblokir email untuk register akun terpisah:
+-- satu akun terdaftar dan satu akun guest per email
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_guest ON users (email, (role = 'guest'));
+COMMIT;
@@ -0,0 +1 @@
+-- nilai enum tidak bisa dihapus; user staff dikembalikan oleh down migration 000017
@@ -1,6 +1,14 @@
 package router
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/didanslmn/movie-reservation-system.git/config"
+	calendarHandler "github.com/didanslmn/movie-reservation-system.git/internal/calendar/handler"
+	calendarRepository "github.com/didanslmn/movie-reservation-system.git/internal/calendar/repository"
+	calendarRouter "github.com/didanslmn/movie-reservation-system.git/internal/calendar/router"
+	calendarService "github.com/didanslmn/movie-reservation-system.git/internal/calendar/service"
 	cinemahallHandler "github.com/didanslmn/movie-reservation-system.git/internal/cinemahall/handler"
 	cinemahallRepository "github.com/didanslmn/movie-reservation-system.git/internal/cinemahall/repository"
 	cinemahallRouter "github.com/didanslmn/movie-reservation-system.git/internal/cinemahall/router"
@@ -14,28 +22,61 @@ import (
 	movieRepository "github.com/didanslmn/movie-reservation-system.git/internal/movie/repository"
 	movieRouter "github.com/didanslmn/movie-reservation-system.git/internal/movie/router"
 	movieService "github.com/didanslmn/movie-reservation-system.git/internal/movie/service"
+	"github.com/didanslmn/movie-reservation-system.git/internal/notification"
+	pricingHandler "github.com/didanslmn/movie-reservation-system.git/internal/pricing/handler"
+	pricingRepository "github.com/didanslmn/movie-reservation-system.git/internal/pricing/repository"
+	pricingRouter "github.com/didanslmn/movie-reservation-system.git/internal/pricing/router"
+	pricingService "github.com/didanslmn/movie-reservation-system.git/internal/pricing/service"
 	reservationHandler "github.com/didanslmn/movie-reservation-system.git/internal/reservation/handler"
 	reservationRepository "github.com/didanslmn/movie-reservation-system.git/internal/reservation/repository"
 	reservationRouter "github.com/didanslmn/movie-reservation-system.git/internal/reservation/router"
 	reservationService "github.com/didanslmn/movie-reservation-system.git/internal/reservation/service"
+	reservationWorker "github.com/didanslmn/movie-reservation-system.git/internal/reservation/worker"
 	seatHandler "github.com/didanslmn/movie-reservation-system.git/internal/seat/handler"
 	seatRepository "github.com/didanslmn/movie-reservation-system.git/internal/seat/repository"
 	seatRouter "github.com/didanslmn/movie-reservation-system.git/internal/seat/router"
 	seatService "github.com/didanslmn/movie-reservation-system.git/internal/seat/service"
+	seatStreamHandler "github.com/didanslmn/movie-reservation-system.git/internal/seatstream/handler"
+	seatStreamRouter "github.com/didanslmn/movie-reservation-system.git/internal/seatstream/router"
+	seatStreamService "github.com/didanslmn/movie-reservation-system.git/internal/seatstream/service"
 	showtimeHandler "github.com/didanslmn/movie-reservation-system.git/internal/showtime/handler"
 	showtimeRepository "github.com/didanslmn/movie-reservation-system.git/internal/showtime/repository"
 	showtimeRouter "github.com/didanslmn/movie-reservation-system.git/internal/showtime/router"
 	showtimeService "github.com/didanslmn/movie-reservation-system.git/internal/showtime/service"
+	ticketHandler "github.com/didanslmn/movie-reservation-system.git/internal/ticket/handler"
+	ticketRepository "github.com/didanslmn/movie-reservation-system.git/internal/ticket/repository"
+	ticketRouter "github.com/didanslmn/movie-reservation-system.git/internal/ticket/router"
+	ticketService "github.com/didanslmn/movie-reservation-system.git/internal/ticket/service"
 	userHandler "github.com/didanslmn/movie-reservation-system.git/internal/users/handler"
 	userRepository "github.com/didanslmn/movie-reservation-system.git/internal/users/repository"
 	userRouter "github.com/didanslmn/movie-reservation-system.git/internal/users/router"
 	userService "github.com/didanslmn/movie-reservation-system.git/internal/users/service"
+	waitlistHandler "github.com/didanslmn/movie-reservation-system.git/internal/waitlist/handler"
+	waitlistRepository "github.com/didanslmn/movie-reservation-system.git/internal/waitlist/repository"
+	waitlistRouter "github.com/didanslmn/movie-reservation-system.git/internal/waitlist/router"
+	waitlistService "github.com/didanslmn/movie-reservation-system.git/internal/waitlist/service"
+	"github.com/didanslmn/movie-reservation-system.git/utils"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func SetupRouter(db *gorm.DB, jwtSecret string) *gin.Engine {
+// SetupRouter menyusun semua modul dan route. Worker latar belakang (sweeper
+// reservation dan dispatcher notifikasi) berjalan sampai ctx dibatalkan.
+func SetupRouter(
+	ctx context.Context,
+	db *gorm.DB,
+	jwtSecret string,
+	appCfg config.AppConfig,
+	reservationCfg config.ReservationConfig,
+	ticketCfg config.TicketConfig,
+	notificationCfg config.NotificationConfig,
+	guestCfg config.GuestConfig,
+	pricingCfg config.PricingConfig,
+) (*gin.Engine, error) {
 	r := gin.Default()
+	if err := r.SetTrustedProxies(appCfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
 	r.Use(middleware.LoggerMiddleware())
 	r.Use(middleware.RecoveryMiddleware())
 
@@ -62,27 +103,91 @@ func SetupRouter(db *gorm.DB, jwtSecret string) *gin.Engine {
 
 	// === Seat Setup ===
 	seatRepo := seatRepository.NewSeatRepository(db)
-	seatSvc := seatService.NewSeatService(seatRepo)
+	seatCategoryRepo := seatRepository.NewSeatCategoryRepository(db)
+	seatSvc := seatService.NewSeatService(seatRepo, seatCategoryRepo)
 	seatHdl := seatHandler.NewSeatHandler(seatSvc)
+	seatCategorySvc := seatService.NewSeatCategoryService(seatCategoryRepo)
+	seatCategoryHdl := seatHandler.NewSeatCategoryHandler(seatCategorySvc)
 
-	// === Showtime Setup ===
+	// === Pricing Setup ===
 	showtimeRepo := showtimeRepository.NewShowtimeRepository(db)
-	showtimeSvc := showtimeService.NewShowtimeService(showtimeRepo, movieRepo, cinemahallRepo)
-	showtimeHdl := showtimeHandler.NewShowtimeHandler(showtimeSvc)
+	priceRuleRepo := pricingRepository.NewPriceRuleRepository(db)
+	pricingSvc := pricingService.NewPricingService(priceRuleRepo, showtimeRepo, seatRepo, seatCategoryRepo, pricingCfg)
+	pricingHdl := pricingHandler.NewPricingHandler(pricingSvc)
 
 	// === Reservation Setup ===
 	reservationRepo := reservationRepository.NewReservationRepository(db)
+	idempotencyRepo := reservationRepository.NewIdempotencyRepository(db)
+	bookingLimitRepo := reservationRepository.NewBookingLimitRepository(db)
+	bookingLimitSvc := reservationService.NewBookingLimitService(bookingLimitRepo, userRepo, reservationCfg)
+	bookingLimitHdl := reservationHandler.NewBookingLimitHandler(bookingLimitSvc)
 	reservationSvc := reservationService.NewReservationService(
 		reservationRepo,
+		idempotencyRepo,
 		userRepo,
 		showtimeRepo,
 		seatRepo,
+		cinemahallRepo,
+		bookingLimitSvc,
+		pricingSvc,
+		reservationCfg,
 	)
 	reservationHdl := reservationHandler.NewReservationHandler(reservationSvc)
+	transferRepo := reservationRepository.NewTransferRepository(db)
+	transferSvc := reservationService.NewTransferService(transferRepo, reservationRepo, userRepo, bookingLimitSvc)
+	transferHdl := reservationHandler.NewTransferHandler(transferSvc)
+	guestRepo := reservationRepository.NewGuestAccessRepository(db)
+	guestSvc := reservationService.NewGuestService(guestRepo, reservationRepo, userRepo, reservationSvc, appCfg.PublicBaseURL, guestCfg)
+	userSvc.VerifyGuestWith(guestSvc.OwnsAccessToken)
+	guestHdl := reservationHandler.NewGuestHandler(guestSvc)
+	reservationWorker.NewExpirySweeper(reservationSvc, reservationCfg.SweepInterval).Start(ctx)
+	reservationWorker.NewCompletionSweeper(reservationSvc, reservationCfg.CompleteInterval).Start(ctx)
+
+	// === Showtime Setup ===
+	// showtime butuh reservation service untuk membatalkan/menjadwalkan ulang reservation
+	showtimeSvc := showtimeService.NewShowtimeService(showtimeRepo, movieRepo, cinemahallRepo, seatRepo, pricingSvc, reservationSvc)
+	showtimeHdl := showtimeHandler.NewShowtimeHandler(showtimeSvc)
+
+	// === Notification Setup ===
+	notifier, err := notification.NewNotifier(notificationCfg)
+	if err != nil {
+		return nil, err
+	}
+	dispatcher := notification.NewDispatcher(notifier, notificationCfg.QueueSize, pricingCfg.Location)
+	dispatcher.Start(ctx)
+	reservationSvc.OnStatusChanged(dispatcher.NotifyReservation)
+	reservationSvc.OnRescheduled(dispatcher.NotifyReschedule)
+	transferSvc.OnTransferRequested(dispatcher.NotifyTransfer)
+	guestSvc.OnAccessIssued(dispatcher.NotifyGuestAccess)
+
+	// === Seat Stream Setup ===
+	seatStreamSvc := seatStreamService.NewSeatStreamService(showtimeRepo)
+	seatStreamHdl := seatStreamHandler.NewSeatStreamHandler(seatStreamSvc)
+	reservationSvc.OnSeatsChanged(seatStreamSvc.NotifySeatsChanged)
+
+	// === Waitlist Setup ===
+	waitlistRepo := waitlistRepository.NewWaitlistRepository(db)
+	waitlistSvc := waitlistService.NewWaitlistService(waitlistRepo, showtimeRepo, seatRepo, reservationSvc, reservationRepo)
+	waitlistHdl := waitlistHandler.NewWaitlistHandler(waitlistSvc)
+	reservationSvc.OnSeatsReleased(waitlistSvc.Promote)
+	waitlistSvc.OnOffered(dispatcher.NotifyWaitlistOffer)
+
+	// === Ticket Setup ===
+	ticketRepo := ticketRepository.NewTicketRepository(db)
+	ticketSvc := ticketService.NewTicketService(ticketRepo, reservationRepo, reservationSvc, guestSvc, ticketCfg)
+	ticketHdl := ticketHandler.NewTicketHandler(ticketSvc)
+
+	// === Calendar Setup ===
+	calendarRepo := calendarRepository.NewCalendarRepository(db)
+	calendarSvc := calendarService.NewCalendarService(calendarRepo, reservationRepo, appCfg.PublicBaseURL)
+	calendarHdl := calendarHandler.NewCalendarHandler(calendarSvc)
 
 	// Register routes
 	api := r.Group("/api/v1")
 	userRouter.AuthRoutes(api, userHdl)
+	calendarRouter.CalendarFeedRoutes(api, calendarHdl)
+	reservationRouter.GuestRoutes(api, guestHdl, utils.NewRateLimiter(guestCfg.CheckoutLimitPerIP, guestCfg.CheckoutLimitWindow))
+	ticketRouter.GuestTicketRoutes(api, ticketHdl)
 
 	Protected := api.Group("/")
 	Protected.Use(authMiddleware)
@@ -91,9 +196,17 @@ func SetupRouter(db *gorm.DB, jwtSecret string) *gin.Engine {
 	genreRouter.GenreRoutes(Protected, genreHdl, jwtSecret)
 	movieRouter.MovieRoutes(Protected, movieHdl, jwtSecret)
 	cinemahallRouter.CinemaHallRouts(Protected, cinemahallHdl, jwtSecret)
-	seatRouter.SeatRouts(Protected, seatHdl, jwtSecret)
+	seatRouter.SeatRouts(Protected, seatHdl, seatCategoryHdl, jwtSecret)
 	showtimeRouter.ShowtimeRoutes(Protected, showtimeHdl, jwtSecret)
+	seatStreamRouter.SeatStreamRoutes(Protected, seatStreamHdl, jwtSecret)
+	pricingRouter.PricingRoutes(Protected, pricingHdl, jwtSecret)
 	reservationRouter.ReservationRoutes(Protected, reservationHdl, jwtSecret)
+	reservationRouter.TransferRoutes(Protected, transferHdl, jwtSecret)
+	reservationRouter.UserReservationRoutes(Protected, reservationHdl, jwtSecret)
+	reservationRouter.BookingLimitRoutes(Protected, bookingLimitHdl, jwtSecret)
+	waitlistRouter.WaitlistRoutes(Protected, waitlistHdl, jwtSecret)
+	ticketRouter.TicketRoutes(Protected, ticketHdl, jwtSecret)
+	calendarRouter.CalendarRoutes(Protected, calendarHdl, jwtSecret)
 
-	return r
+	return r, nil
 }
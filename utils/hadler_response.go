@@ -9,6 +9,7 @@ import (
 type ErrorResponse struct {
 	Message string `json:"message"`
 	Error   string `json:"error,omitempty"`
+	Details any    `json:"details,omitempty"`
 }
 
 type SuccessResponse struct {
@@ -24,6 +25,17 @@ func RespondWithError(c *gin.Context, code int, msg string, err error) {
 	})
 }
 
+// RespondWithErrorDetails sama seperti RespondWithError, ditambah data
+// pendukung (misalnya daftar seat yang bentrok) untuk client.
+func RespondWithErrorDetails(c *gin.Context, code int, msg string, err error, details any) {
+	ErrorLogger.Printf("%s: %v", msg, err)
+	c.JSON(code, ErrorResponse{
+		Message: msg,
+		Error:   err.Error(),
+		Details: details,
+	})
+}
+
 func RespondWithSuccess(c *gin.Context, msg string, data interface{}) {
 	c.JSON(http.StatusOK, SuccessResponse{
 		Message: msg,